@@ -0,0 +1,95 @@
+package params
+
+import (
+	"errors"
+	"io/fs"
+	"net/http"
+	"net/url"
+
+	"github.com/Nivl/go-params/formfile"
+	"github.com/Nivl/go-params/perror"
+	"github.com/Nivl/go-types/slices"
+)
+
+// defaultMaxMemory is the amount of memory used to parse a multipart form.
+// Same value as the one used by net/http
+const defaultMaxMemory = 32 << 20 // 32 MB
+
+// Bind allocates a new T, fills it using the provided sources, and
+// validates it. T needs to be a struct, ErrNotStructPointer is returned
+// otherwise
+func Bind[T any](sources map[string]url.Values, fileHolder formfile.FileHolder) (*T, error) {
+	data := new(T)
+	if err := New(data).Parse(sources, fileHolder); err != nil {
+		return nil, err
+	}
+	return data, nil
+}
+
+// BindRequest allocates a new T, fills it using the data of the request,
+// and validates it. T needs to be a struct.
+// The sources are built from:
+//   - url: r.PathValue() (Go 1.22+ only)
+//   - query: the query string
+//   - form: the urlencoded or multipart/form-data body
+//   - header: the headers, matched without taking care of their case
+//   - file: the files of the multipart/form-data body
+func BindRequest[T any](r *http.Request) (*T, error) {
+	// ParseMultipartForm ignores the errors of ParseForm when the body
+	// is not a multipart form
+	if err := r.ParseForm(); err != nil {
+		return nil, bodyError(err)
+	}
+	if err := r.ParseMultipartForm(defaultMaxMemory); err != nil && err != http.ErrNotMultipart {
+		return nil, bodyError(err)
+	}
+
+	sources := map[string]url.Values{
+		"url":    requestURLValues(r, new(T)),
+		"query":  r.URL.Query(),
+		"form":   r.PostForm,
+		"header": requestHeaders(r, new(T)),
+	}
+	return Bind[T](sources, formfile.NewRequestHolder(r))
+}
+
+// bodyError converts the error returned when parsing the body of a
+// request. A malformed body (missing boundary, invalid encoding, body
+// too large, ...) is a user error, like in SetFile. The temporary files
+// of a multipart form that cannot be created are system errors
+func bodyError(err error) error {
+	var pathErr *fs.PathError
+	if errors.As(err, &pathErr) {
+		return err
+	}
+	return perror.New("", err.Error())
+}
+
+// requestHeaders returns the header source of a request. The keys of
+// http.Header are canonicalized (X-Api-Token), so the headers are also
+// stored using the keys of the params (x-api-token) to be found
+// whatever the case used in the tags
+func requestHeaders(r *http.Request, data interface{}) url.Values {
+	headers := url.Values{}
+	for k, v := range r.Header {
+		headers[k] = v
+	}
+
+	// An invalid struct will be reported when parsed
+	fields, err := New(data).Describe()
+	if err != nil {
+		return headers
+	}
+	for _, field := range fields {
+		if !slices.HasString("header", field.Sources) {
+			continue
+		}
+		for _, key := range field.Options.Keys() {
+			// Values() canonicalizes the key
+			if values := r.Header.Values(key); len(values) > 0 {
+				headers[key] = values
+			}
+		}
+	}
+	return headers
+}
@@ -0,0 +1,176 @@
+package params_test
+
+import (
+	"bytes"
+	"errors"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	params "github.com/Nivl/go-params"
+	"github.com/Nivl/go-params/formfile"
+	"github.com/Nivl/go-params/perror"
+)
+
+func TestBind(t *testing.T) {
+	t.Run("valid struct", subTestBindValid)
+	t.Run("invalid data", subTestBindInvalidData)
+	t.Run("not a struct", subTestBindNotAStruct)
+}
+
+func TestBindRequest(t *testing.T) {
+	t.Run("urlencoded", subTestBindRequestURLEncoded)
+	t.Run("multipart", subTestBindRequestMultipart)
+	t.Run("headers", subTestBindRequestHeaders)
+	t.Run("invalid multipart", subTestBindRequestInvalidMultipart)
+	t.Run("invalid urlencoded body", subTestBindRequestInvalidURLEncoded)
+}
+
+func subTestBindValid(t *testing.T) {
+	t.Parallel()
+
+	type strct struct {
+		ID   string `from:"url" json:"id" params:"required"`
+		Page int    `from:"query" json:"page" default:"1"`
+	}
+
+	sources := map[string]url.Values{
+		"url":   {"id": []string{"42"}},
+		"query": {},
+	}
+	s, err := params.Bind[strct](sources, nil)
+	require.NoError(t, err, "Bind() should not have failed")
+	assert.Equal(t, &strct{ID: "42", Page: 1}, s, "Bind() returned unexpected data")
+}
+
+func subTestBindInvalidData(t *testing.T) {
+	t.Parallel()
+
+	type strct struct {
+		ID string `from:"url" json:"id" params:"required"`
+	}
+
+	sources := map[string]url.Values{
+		"url": {},
+	}
+	s, err := params.Bind[strct](sources, nil)
+	require.Error(t, err, "Bind() should have failed")
+	assert.Nil(t, s, "Bind() should not have returned data")
+	assert.Equal(t, perror.New("id", params.ErrMsgMissingParameter), err, "Bind() returned an unexpected error")
+}
+
+func subTestBindNotAStruct(t *testing.T) {
+	t.Parallel()
+
+	_, err := params.Bind[string](nil, nil)
+	require.Error(t, err, "Bind() should have failed")
+	assert.True(t, errors.Is(err, params.ErrNotStructPointer), "Bind() returned an unexpected error")
+
+	_, err = params.Bind[*struct{}](nil, nil)
+	require.Error(t, err, "Bind() should have failed")
+	assert.True(t, errors.Is(err, params.ErrNotStructPointer), "Bind() returned an unexpected error")
+}
+
+func subTestBindRequestURLEncoded(t *testing.T) {
+	t.Parallel()
+
+	type strct struct {
+		Page  int    `from:"query" json:"page"`
+		Name  string `from:"form" json:"name" params:"required"`
+		Token string `from:"header" json:"X-Token"`
+	}
+
+	req := httptest.NewRequest(http.MethodPost, "/items?page=2", strings.NewReader("name=item"))
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.Header.Set("X-Token", "secret")
+
+	s, err := params.BindRequest[strct](req)
+	require.NoError(t, err, "BindRequest() should not have failed")
+	assert.Equal(t, &strct{Page: 2, Name: "item", Token: "secret"}, s, "BindRequest() returned unexpected data")
+}
+
+func subTestBindRequestMultipart(t *testing.T) {
+	t.Parallel()
+
+	type strct struct {
+		Name string             `from:"form" json:"name"`
+		File *formfile.FormFile `from:"file" json:"file" params:"required"`
+	}
+
+	body := &bytes.Buffer{}
+	w := multipart.NewWriter(body)
+	require.NoError(t, w.WriteField("name", "item"), "WriteField() should not have failed")
+	part, err := w.CreateFormFile("file", "pixel.png")
+	require.NoError(t, err, "CreateFormFile() should not have failed")
+	_, err = part.Write(newPNG(t, 1, 1))
+	require.NoError(t, err, "Write() should not have failed")
+	require.NoError(t, w.Close(), "Close() should not have failed")
+
+	req := httptest.NewRequest(http.MethodPost, "/items", body)
+	req.Header.Set("Content-Type", w.FormDataContentType())
+
+	s, err := params.BindRequest[strct](req)
+	require.NoError(t, err, "BindRequest() should not have failed")
+	assert.Equal(t, "item", s.Name, "Wrong name")
+	require.NotNil(t, s.File, "File should not be nil")
+	assert.Equal(t, "image/png", s.File.Mime, "Wrong mime type")
+}
+
+func subTestBindRequestHeaders(t *testing.T) {
+	t.Parallel()
+
+	type strct struct {
+		Token     string   `from:"header" json:"x-api-token" params:"required"`
+		RequestID string   `from:"header" json:"X-REQUEST-ID"`
+		Tags      []string `from:"header,query" json:"x-tag" alias:"tag"`
+	}
+
+	req := httptest.NewRequest(http.MethodGet, "/items", nil)
+	req.Header.Set("X-Api-Token", "secret")
+	req.Header.Set("x-request-id", "42")
+	req.Header.Add("Tag", "a")
+	req.Header.Add("Tag", "b")
+
+	s, err := params.BindRequest[strct](req)
+	require.NoError(t, err, "BindRequest() should not have failed")
+	assert.Equal(t, &strct{Token: "secret", RequestID: "42", Tags: []string{"a", "b"}}, s, "BindRequest() returned unexpected data")
+}
+
+func subTestBindRequestInvalidMultipart(t *testing.T) {
+	t.Parallel()
+
+	type strct struct {
+		Name string `from:"form" json:"name"`
+	}
+
+	req := httptest.NewRequest(http.MethodPost, "/items", strings.NewReader("name=item"))
+	req.Header.Set("Content-Type", "multipart/form-data")
+
+	_, err := params.BindRequest[strct](req)
+	require.Error(t, err, "BindRequest() should have failed")
+	perr, ok := err.(perror.Error)
+	require.True(t, ok, "a malformed body should be a user error")
+	assert.Equal(t, http.ErrMissingBoundary.Error(), perr.Error(), "BindRequest() should return the parsing error")
+}
+
+func subTestBindRequestInvalidURLEncoded(t *testing.T) {
+	t.Parallel()
+
+	type strct struct {
+		Name string `from:"form" json:"name"`
+	}
+
+	req := httptest.NewRequest(http.MethodPost, "/items", strings.NewReader("name=%zz"))
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+	_, err := params.BindRequest[strct](req)
+	require.Error(t, err, "BindRequest() should have failed")
+	_, ok := err.(perror.Error)
+	assert.True(t, ok, "a malformed body should be a user error")
+}
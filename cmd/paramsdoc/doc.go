@@ -0,0 +1,128 @@
+package main
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	params "github.com/Nivl/go-params"
+	"github.com/Nivl/go-params/internal/structscan"
+)
+
+// packageDoc contains the documentation of a package
+type packageDoc struct {
+	Name    string
+	Structs []*structDoc
+}
+
+// structDoc contains the documentation of a params struct
+type structDoc struct {
+	Name   string
+	Doc    string
+	Fields []*fieldDoc
+}
+
+// fieldDoc contains the documentation of a param
+type fieldDoc struct {
+	Name        string
+	Source      string
+	Type        string
+	Required    bool
+	Default     string
+	Enum        string
+	Constraints string
+}
+
+// newPackageDoc generates the documentation of all the params structs
+// of a package
+func newPackageDoc(pkg *structscan.Package, strategy params.NamingStrategy) (*packageDoc, error) {
+	doc := &packageDoc{Name: pkg.Name}
+	for _, s := range pkg.ParamsStructs() {
+		list, err := pkg.Params(s, strategy)
+		if err != nil {
+			return nil, fmt.Errorf("struct %s: %s", s.Name, err.Error())
+		}
+
+		sDoc := &structDoc{
+			Name: s.Name,
+			Doc:  s.Doc,
+		}
+		for _, param := range list {
+			sDoc.Fields = append(sDoc.Fields, &fieldDoc{
+				Name:        param.Name,
+				Source:      strings.Join(param.Sources, ", "),
+				Type:        param.Field.Type,
+				Required:    param.Options.Required,
+				Default:     param.Default,
+				Enum:        strings.Join(param.Options.AuthorizedValues, ", "),
+				Constraints: strings.Join(constraints(param.Options), "; "),
+			})
+		}
+		doc.Structs = append(doc.Structs, sDoc)
+	}
+	return doc, nil
+}
+
+// constraints returns a human readable version of all the constraints
+// of a param
+func constraints(opts *params.Options) []string {
+	c := []string{}
+	addInt := func(label string, v *int) {
+		if v != nil {
+			c = append(c, label+" "+strconv.Itoa(*v))
+		}
+	}
+	addSize := func(label string, v *int64) {
+		if v != nil {
+			c = append(c, label+" "+strconv.FormatInt(*v, 10)+" bytes")
+		}
+	}
+	addFlag := func(label string, enabled bool) {
+		if enabled {
+			c = append(c, label)
+		}
+	}
+	addList := func(label string, v []string) {
+		if len(v) > 0 {
+			c = append(c, label+": "+strings.Join(v, ", "))
+		}
+	}
+
+	addFlag("uuid", opts.ValidateUUID)
+	addFlag("email", opts.ValidateEmail)
+	addFlag("url", opts.ValidateURL)
+	addFlag("slug", opts.ValidateSlug)
+	addFlag("slug or uuid", opts.ValidateSlugOrUUID)
+	addFlag("image", opts.ValidateImage)
+	addFlag("not empty", opts.NoEmpty)
+	addFlag("no empty items", opts.NoEmptyItems)
+	addFlag("trimmed", opts.Trim)
+	if opts.MaxLen > 0 {
+		c = append(c, "max length "+strconv.Itoa(opts.MaxLen))
+	}
+	addInt("min", opts.MinInt)
+	addInt("max", opts.MaxInt)
+	addInt("min items", opts.MinItems)
+	addInt("max items", opts.MaxItems)
+	addSize("min size", opts.MinSize)
+	addSize("max size", opts.MaxSize)
+	addList("mime", opts.AuthorizedMimes)
+	addList("extensions", opts.AuthorizedExtensions)
+	addFlag("strict mime", opts.StrictMime)
+	addInt("min width", opts.MinWidth)
+	addInt("max width", opts.MaxWidth)
+	addInt("min height", opts.MinHeight)
+	addInt("max height", opts.MaxHeight)
+	if opts.AspectRatio != nil {
+		c = append(c, fmt.Sprintf("aspect ratio %d:%d", opts.AspectRatio.Width, opts.AspectRatio.Height))
+	}
+	if opts.ChecksumField != "" {
+		c = append(c, "checksum in "+opts.ChecksumField)
+	}
+	addFlag("sources must agree", opts.ConflictError)
+	addList("aliases", opts.Aliases)
+	if opts.Deprecated != "" {
+		c = append(c, "deprecated: "+opts.Deprecated)
+	}
+	return c
+}
@@ -0,0 +1,75 @@
+// Command paramsdoc generates the documentation of the params structs
+// of a Go package.
+//
+// Usage:
+//
+//	paramsdoc [-format markdown|html] [-naming snake|camel|kebab] [-o file] [dir]
+//
+// The package in the current directory is used if no dir is provided
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/Nivl/go-params/internal/structscan"
+)
+
+func main() {
+	if err := run(os.Args[1:], os.Stdout); err != nil {
+		fmt.Fprintln(os.Stderr, "paramsdoc:", err)
+		os.Exit(1)
+	}
+}
+
+// run runs the command using the provided arguments. The documentation
+// is written to stdout if no output files are provided
+func run(args []string, stdout io.Writer) (err error) {
+	flags := flag.NewFlagSet("paramsdoc", flag.ContinueOnError)
+	format := flags.String("format", "markdown", "output format: markdown or html")
+	naming := flags.String("naming", "", "naming strategy of the untagged fields: snake, camel, or kebab")
+	output := flags.String("o", "", "output file (default stdout)")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+
+	dir := "."
+	if flags.NArg() > 0 {
+		dir = flags.Arg(0)
+	}
+
+	strategy, err := structscan.NamingStrategy(*naming)
+	if err != nil {
+		return err
+	}
+	render, found := renderers[*format]
+	if !found {
+		return fmt.Errorf("unknown format %s", *format)
+	}
+
+	pkg, err := structscan.Load(dir)
+	if err != nil {
+		return err
+	}
+	doc, err := newPackageDoc(pkg, strategy)
+	if err != nil {
+		return err
+	}
+
+	w := stdout
+	if *output != "" {
+		f, err := os.Create(*output)
+		if err != nil {
+			return err
+		}
+		defer func() {
+			if closeErr := f.Close(); err == nil {
+				err = closeErr
+			}
+		}()
+		w = f
+	}
+	return render(w, doc)
+}
@@ -0,0 +1,66 @@
+package main
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRunMarkdown(t *testing.T) {
+	t.Parallel()
+
+	out := &bytes.Buffer{}
+	err := run([]string{"-naming", "snake", "testdata/api"}, out)
+	require.NoError(t, err, "run() should have succeed")
+
+	doc := out.String()
+	assert.Contains(t, doc, "# Package api\n", "the package should be documented")
+	assert.Contains(t, doc, "## Pagination\n\nPagination contains the params used to paginate a list\n", "the struct should be documented")
+	assert.Contains(t, doc, "| `page` | query | `int` |  | 1 |  | min 1 |\n", "page should be documented")
+	assert.Contains(t, doc, "| `per_page` | query | `int` |  |  |  | max 100; aliases: perPage, size |\n", "the naming strategy should be used")
+	assert.Contains(t, doc, "| `filter` | query, form | `string` | yes |  |  | max length 10 |\n", "filter should be documented")
+	assert.Contains(t, doc, "| `sort` | query | `string` |  |  | name, created_at |  |\n", "sort should be documented")
+	assert.NotContains(t, doc, "secret", "ignored fields should not be documented")
+	assert.NotContains(t, doc, "Secret", "ignored fields should not be documented")
+	assert.NotContains(t, doc, "## user", "only the params structs should be documented")
+}
+
+func TestRunHTML(t *testing.T) {
+	t.Parallel()
+
+	output := filepath.Join(t.TempDir(), "doc.html")
+	err := run([]string{"-format", "html", "-o", output, "testdata/api"}, &bytes.Buffer{})
+	require.NoError(t, err, "run() should have succeed")
+
+	content, err := os.ReadFile(output)
+	require.NoError(t, err, "the output file should exist")
+	doc := string(content)
+	assert.Contains(t, doc, `<h2 id="ListUsersParams">ListUsersParams</h2>`, "the struct should be documented")
+	assert.Contains(t, doc, "<tr><td><code>PerPage</code></td><td>query</td><td><code>int</code></td><td></td><td></td><td></td><td>max 100; aliases: perPage, size</td></tr>", "the go name should be used by default")
+	assert.Contains(t, doc, "<td>name, created_at</td>", "the enum should be documented")
+}
+
+func TestRunErrors(t *testing.T) {
+	testCases := []struct {
+		description string
+		args        []string
+	}{
+		{"unknown format", []string{"-format", "pdf", "testdata/api"}},
+		{"unknown naming strategy", []string{"-naming", "pascal", "testdata/api"}},
+		{"unknown directory", []string{"testdata/does-not-exist"}},
+		{"unknown flag", []string{"-unknown"}},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.description, func(t *testing.T) {
+			t.Parallel()
+			err := run(tc.args, &bytes.Buffer{})
+			assert.Error(t, err, "run() should have failed")
+		})
+	}
+}
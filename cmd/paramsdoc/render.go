@@ -0,0 +1,95 @@
+package main
+
+import (
+	"fmt"
+	"html/template"
+	"io"
+	"strings"
+)
+
+// renderers contains the functions used to render the documentation,
+// by format
+var renderers = map[string]func(w io.Writer, doc *packageDoc) error{
+	"markdown": renderMarkdown,
+	"md":       renderMarkdown,
+	"html":     renderHTML,
+}
+
+// markdownEscaper escapes the characters that would break a table cell
+var markdownEscaper = strings.NewReplacer("|", `\|`, "\n", " ")
+
+// renderMarkdown renders the documentation as Markdown
+func renderMarkdown(w io.Writer, doc *packageDoc) error {
+	b := &strings.Builder{}
+	fmt.Fprintf(b, "# Package %s\n", doc.Name)
+	for _, s := range doc.Structs {
+		fmt.Fprintf(b, "\n## %s\n\n", s.Name)
+		if s.Doc != "" {
+			fmt.Fprintf(b, "%s\n\n", s.Doc)
+		}
+		b.WriteString("| Name | Source | Type | Required | Default | Enum | Constraints |\n")
+		b.WriteString("| --- | --- | --- | --- | --- | --- | --- |\n")
+		for _, f := range s.Fields {
+			cells := []string{
+				"`" + f.Name + "`",
+				f.Source,
+				"`" + f.Type + "`",
+				yesNo(f.Required),
+				f.Default,
+				f.Enum,
+				f.Constraints,
+			}
+			for i, cell := range cells {
+				cells[i] = markdownEscaper.Replace(cell)
+			}
+			fmt.Fprintf(b, "| %s |\n", strings.Join(cells, " | "))
+		}
+	}
+
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+// htmlTemplate is the template used to render the documentation as HTML
+var htmlTemplate = template.Must(template.New("paramsdoc").Funcs(template.FuncMap{
+	"yesNo": yesNo,
+}).Parse(`<!DOCTYPE html>
+<html>
+<head>
+<meta charset="utf-8">
+<title>Package {{.Name}}</title>
+</head>
+<body>
+<h1>Package {{.Name}}</h1>
+{{- range .Structs}}
+<h2 id="{{.Name}}">{{.Name}}</h2>
+{{- if .Doc}}
+<p>{{.Doc}}</p>
+{{- end}}
+<table>
+<thead>
+<tr><th>Name</th><th>Source</th><th>Type</th><th>Required</th><th>Default</th><th>Enum</th><th>Constraints</th></tr>
+</thead>
+<tbody>
+{{- range .Fields}}
+<tr><td><code>{{.Name}}</code></td><td>{{.Source}}</td><td><code>{{.Type}}</code></td><td>{{yesNo .Required}}</td><td>{{.Default}}</td><td>{{.Enum}}</td><td>{{.Constraints}}</td></tr>
+{{- end}}
+</tbody>
+</table>
+{{- end}}
+</body>
+</html>
+`))
+
+// renderHTML renders the documentation as a HTML page
+func renderHTML(w io.Writer, doc *packageDoc) error {
+	return htmlTemplate.Execute(w, doc)
+}
+
+// yesNo returns "yes" if the value is true, and an empty string otherwise
+func yesNo(v bool) string {
+	if v {
+		return "yes"
+	}
+	return ""
+}
@@ -0,0 +1,20 @@
+package api
+
+// Pagination contains the params used to paginate a list
+type Pagination struct {
+	Page    int `from:"query" json:"page" min_int:"1" default:"1"`
+	PerPage int `from:"query" alias:"perPage,size" max_int:"100"`
+}
+
+// ListUsersParams contains the params of the endpoint listing the users
+type ListUsersParams struct {
+	*Pagination
+	Sort   string `from:"query" json:"sort" enum:"name,created_at"`
+	Filter string `from:"query,form" json:"filter" params:"required" maxlen:"10"`
+	Secret string `from:"query" json:"-"`
+}
+
+// user is not a params struct
+type user struct {
+	ID string `json:"id"`
+}
@@ -0,0 +1,680 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"go/format"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+
+	params "github.com/Nivl/go-params"
+	"github.com/Nivl/go-params/internal/structscan"
+)
+
+const (
+	// directive is the directive to add to the doc comment of a struct
+	// to generate its methods
+	directive = "params:generate"
+
+	paramsPath   = "github.com/Nivl/go-params"
+	genPath      = "github.com/Nivl/go-params/gen"
+	formfilePath = "github.com/Nivl/go-params/formfile"
+	perrorPath   = "github.com/Nivl/go-params/perror"
+)
+
+// Kinds of values supported by the generated code
+const (
+	kindString  = "string"
+	kindBool    = "bool"
+	kindInt     = "int"
+	kindScanner = "scanner"
+	kindFile    = "file"
+	kindTime    = "time"
+)
+
+// fieldType represents the type of a field
+type fieldType struct {
+	// Name contains the type as written in the code
+	Name string
+
+	// Base contains the type of the value, without slices or pointers
+	Base string
+
+	// Kind contains the kind of the base type
+	Kind string
+
+	// Slice means the field is a slice
+	Slice bool
+
+	// Pointer means the field, or the items of the slice, are pointers
+	Pointer bool
+
+	// PackageName and PackagePath contain the package of the base type,
+	// if it's declared in another package
+	PackageName string
+	PackagePath string
+}
+
+// generator generates the code of a package
+type generator struct {
+	pkg *structscan.Package
+
+	// imports contains the packages used by the generated code, by name
+	imports map[string]string
+
+	// options contains the declarations of the Options used by the
+	// generated code, by variable name
+	options map[string]string
+
+	// optionVars contains the variable names of the Options, by
+	// Struct.Field
+	optionVars map[string]string
+
+	// fields contains the variables of the Options used by the struct
+	// being generated
+	fields []string
+
+	parse   bytes.Buffer
+	extract bytes.Buffer
+}
+
+// generate returns the code of the methods of all the annotated structs
+// of the package
+func generate(pkg *structscan.Package) ([]byte, error) {
+	g := &generator{
+		pkg: pkg,
+		imports: map[string]string{
+			"url": "net/url",
+			"gen": genPath,
+		},
+		options:    map[string]string{},
+		optionVars: map[string]string{},
+	}
+
+	body := &bytes.Buffer{}
+	for _, s := range pkg.Structs {
+		if !s.HasDirective(directive) {
+			continue
+		}
+		if err := g.generateStruct(s); err != nil {
+			return nil, fmt.Errorf("%s: %w", s.Name, err)
+		}
+		fmt.Fprintf(body, "// ParseParams fills the struct using the provided sources.\n")
+		fmt.Fprintf(body, "// It's used by params.Parse instead of reflection\n")
+		fmt.Fprintf(body, "func (p *%s) ParseParams(sources map[string]url.Values, fileHolder formfile.FileHolder) error {\n", s.Name)
+		g.checkFields(body, "err")
+		body.Write(bytes.TrimLeft(g.parse.Bytes(), "\n"))
+		fmt.Fprintf(body, "return nil\n}\n\n")
+		fmt.Fprintf(body, "// ExtractParams returns the sources and files that would be\n")
+		fmt.Fprintf(body, "// used to fill the struct. It's used by params.Extract instead of\n")
+		fmt.Fprintf(body, "// reflection\n")
+		fmt.Fprintf(body, "func (p *%s) ExtractParams() (sources map[string]url.Values, files map[string]*formfile.FormFile, multipleFiles map[string][]*formfile.FormFile, err error) {\n", s.Name)
+		g.checkFields(body, "nil, nil, nil, err")
+		fmt.Fprintf(body, "sources = map[string]url.Values{}\n")
+		fmt.Fprintf(body, "files = map[string]*formfile.FormFile{}\n")
+		fmt.Fprintf(body, "multipleFiles = map[string][]*formfile.FormFile{}\n")
+		body.Write(g.extract.Bytes())
+		fmt.Fprintf(body, "return sources, files, multipleFiles, nil\n}\n\n")
+		g.parse.Reset()
+		g.extract.Reset()
+		g.fields = nil
+	}
+	if body.Len() == 0 {
+		return nil, fmt.Errorf("no structs of package %s are annotated with //%s", pkg.Name, directive)
+	}
+	// the methods always use the formfile package
+	g.imports["formfile"] = formfilePath
+
+	out := &bytes.Buffer{}
+	fmt.Fprintf(out, "// Code generated by paramsgen. DO NOT EDIT.\n\n")
+	fmt.Fprintf(out, "package %s\n\n", pkg.Name)
+	writeImports(out, g.imports)
+	fmt.Fprintf(out, "// The options of the fields are parsed once, when the package is\n")
+	fmt.Fprintf(out, "// initialized. The invalid tags are reported by the methods\n")
+	fmt.Fprintf(out, "var (\n")
+	for _, name := range sortedKeys(g.options) {
+		fmt.Fprintf(out, "%s = %s\n", name, g.options[name])
+	}
+	fmt.Fprintf(out, ")\n\n")
+	out.Write(body.Bytes())
+
+	code, err := format.Source(out.Bytes())
+	if err != nil {
+		return nil, fmt.Errorf("could not format the generated code: %w", err)
+	}
+	return code, nil
+}
+
+// writeImports writes the import declaration of the generated code. The
+// packages of the standard library come first
+func writeImports(w io.Writer, imports map[string]string) {
+	std := []string{}
+	others := []string{}
+	for _, name := range sortedKeys(imports) {
+		path := imports[name]
+		spec := strconv.Quote(path)
+		if name != path[strings.LastIndex(path, "/")+1:] {
+			spec = name + " " + spec
+		}
+		if strings.Contains(strings.Split(path, "/")[0], ".") {
+			others = append(others, spec)
+			continue
+		}
+		std = append(std, spec)
+	}
+	sort.Strings(std)
+	sort.Strings(others)
+
+	fmt.Fprintf(w, "import (\n")
+	for _, spec := range std {
+		fmt.Fprintf(w, "%s\n", spec)
+	}
+	fmt.Fprintf(w, "\n")
+	for _, spec := range others {
+		fmt.Fprintf(w, "%s\n", spec)
+	}
+	fmt.Fprintf(w, ")\n\n")
+}
+
+// checkFields generates the code returning the error of the first field
+// of the struct having an invalid tag. ret contains the values to return
+func (g *generator) checkFields(w io.Writer, ret string) {
+	if len(g.fields) == 0 {
+		return
+	}
+	fmt.Fprintf(w, "if err := gen.Check(\n")
+	for _, name := range g.fields {
+		fmt.Fprintf(w, "%s,\n", name)
+	}
+	fmt.Fprintf(w, "); err != nil {\nreturn %s\n}\n\n", ret)
+}
+
+// generateStruct generates the body of the methods of a struct
+func (g *generator) generateStruct(s *structscan.Struct) error {
+	return g.generateFields(s, "p.", map[string]bool{})
+}
+
+// generateFields generates the code handling the fields of a struct.
+// path contains the expression used to access the fields of the struct
+func (g *generator) generateFields(s *structscan.Struct, path string, visited map[string]bool) error {
+	if visited[s.Name] {
+		return fmt.Errorf("struct %s embeds itself", s.Name)
+	}
+	visited[s.Name] = true
+	defer delete(visited, s.Name)
+
+	// files that need to be checked against a checksum, with the field
+	// containing the checksum. The checks are done once all the fields
+	// have been parsed
+	type checksum struct {
+		opts, file, value string
+		pointer           bool
+	}
+	withChecksum := []*checksum{}
+
+	for _, field := range s.Fields {
+		if field.Embedded {
+			if err := g.generateEmbedded(s, field, path, visited); err != nil {
+				return err
+			}
+			continue
+		}
+
+		if !field.Exported {
+			return fmt.Errorf("field %s could not be set", field.Name)
+		}
+		from := strings.ToLower(field.Tag.Get("from"))
+		if from == "" {
+			return fmt.Errorf("no source set for field %s", field.Name)
+		}
+		locations := strings.Split(from, ",")
+		for i, location := range locations {
+			locations[i] = strings.TrimSpace(location)
+		}
+
+		opts, err := params.NewOptions(&field.Tag)
+		if err != nil {
+			return fmt.Errorf("field %s: %w", field.Name, err)
+		}
+		if opts.Ignore {
+			continue
+		}
+		if opts.Name == "" {
+			opts.Name = field.Name
+		}
+		if opts.Deprecated != "" {
+			return fmt.Errorf("field %s: deprecated params are not supported since they need to emit warnings", field.Name)
+		}
+
+		typ, err := g.fieldType(s, field.Type)
+		if err != nil {
+			return fmt.Errorf("field %s: %w", field.Name, err)
+		}
+
+		optsVar := g.addOptions(s, field)
+		target := path + field.Name
+		isFile := locations[0] == "file"
+		switch {
+		case isFile && len(locations) > 1:
+			return fmt.Errorf("field %s: the file source cannot be combined with other sources", field.Name)
+		case isFile && typ.Kind != kindFile:
+			return fmt.Errorf("field %s: the only accepted type for a file is *formfile.FormFile or []*formfile.FormFile, got %s", field.Name, field.Type)
+		case !isFile && typ.Kind == kindFile:
+			return fmt.Errorf("field %s: files need to use the file source", field.Name)
+		case isFile:
+			g.parseFile(typ, optsVar, target)
+			if opts.ChecksumField == "" {
+				break
+			}
+			if typ.Slice {
+				return fmt.Errorf("field %s: checksum_field cannot be used on multiple files", field.Name)
+			}
+			value, pointer, err := g.checksumField(s, opts.ChecksumField, path)
+			if err != nil {
+				return fmt.Errorf("checksum of field %s: %w", field.Name, err)
+			}
+			withChecksum = append(withChecksum, &checksum{optsVar, target, value, pointer})
+		default:
+			g.parseValue(typ, optsVar, target, locations, field.Tag.Get("default"))
+		}
+		g.extractValue(typ, field, optsVar, target, locations[0])
+	}
+
+	for _, c := range withChecksum {
+		fmt.Fprintf(&g.parse, "\n")
+		if c.pointer {
+			fmt.Fprintf(&g.parse, "if %s != nil && %s != nil && *%s != \"\" {\n", c.file, c.value, c.value)
+			fmt.Fprintf(&g.parse, "if err := %s.ValidateChecksum(*%s, %s.SHA256, %s.MD5); err != nil {\nreturn err\n}\n}\n", c.opts, c.value, c.file, c.file)
+			continue
+		}
+		fmt.Fprintf(&g.parse, "if %s != nil && %s != \"\" {\n", c.file, c.value)
+		fmt.Fprintf(&g.parse, "if err := %s.ValidateChecksum(%s, %s.SHA256, %s.MD5); err != nil {\nreturn err\n}\n}\n", c.opts, c.value, c.file, c.file)
+	}
+	return nil
+}
+
+// generateEmbedded generates the code handling an embedded struct
+func (g *generator) generateEmbedded(s *structscan.Struct, field *structscan.Field, path string, visited map[string]bool) error {
+	embedded := g.pkg.EmbeddedStruct(field)
+	if embedded == nil {
+		return fmt.Errorf("embedded field %s: only the structs of the package can be embedded", field.Type)
+	}
+	target := path + field.Name
+	isPointer := strings.HasPrefix(field.Type, "*")
+
+	// We parse the embedded struct, and use its custom validator
+	// if it has one
+	fmt.Fprintf(&g.parse, "\n// embedded %s\n", field.Type)
+	if isPointer {
+		fmt.Fprintf(&g.parse, "if %s == nil {\n%s = new(%s)\n}\n", target, target, embedded.Name)
+	}
+	extract := g.extract.Len()
+	if err := g.generateFields(embedded, target+".", visited); err != nil {
+		return err
+	}
+	addr := target
+	if !isPointer {
+		addr = "&" + target
+	}
+	g.imports["params"] = paramsPath
+	g.imports["perror"] = perrorPath
+	fmt.Fprintf(&g.parse, "\nif validator, ok := interface{}(%s).(params.CustomValidation); ok {\n", addr)
+	fmt.Fprintf(&g.parse, "isValid, field, err := validator.IsValid()\nif !isValid {\nreturn perror.New(field, err.Error())\n}\n}\n")
+
+	// The embedded struct is skipped when it's a nil pointer, or
+	// when it's ignored
+	code := append([]byte{}, bytes.TrimLeft(g.extract.Bytes()[extract:], "\n")...)
+	g.extract.Truncate(extract)
+	if name, _ := nameTag(field); name == "-" {
+		return nil
+	}
+	if isPointer {
+		fmt.Fprintf(&g.extract, "if %s != nil {\n%s}\n", target, code)
+		return nil
+	}
+	g.extract.Write(code)
+	return nil
+}
+
+// parseFile generates the code parsing a file
+func (g *generator) parseFile(typ *fieldType, optsVar, target string) {
+	fmt.Fprintf(&g.parse, "\n// %s\n", target)
+	if typ.Slice {
+		fmt.Fprintf(&g.parse, "if files, err := gen.FormFiles(fileHolder, %s); err != nil {\nreturn err\n} else if len(files) > 0 {\n%s = files\n}\n", optsVar, target)
+		return
+	}
+	fmt.Fprintf(&g.parse, "if file, err := gen.FormFile(fileHolder, %s); err != nil {\nreturn err\n} else if file != nil {\n%s = file\n}\n", optsVar, target)
+}
+
+// parseValue generates the code parsing a value coming from the sources
+func (g *generator) parseValue(typ *fieldType, optsVar, target string, locations []string, defaultValue string) {
+	w := &g.parse
+	quoted := make([]string, len(locations))
+	for i, location := range locations {
+		quoted[i] = strconv.Quote(location)
+	}
+
+	fmt.Fprintf(w, "\n// %s\n", target)
+	fmt.Fprintf(w, "{\nsource, err := gen.SelectSource(sources, %s, %s)\nif err != nil {\nreturn err\n}\n", optsVar, strings.Join(quoted, ", "))
+	if typ.Slice {
+		fmt.Fprintf(w, "values, provided, err := gen.FieldValues(source, %s, %s)\nif err != nil {\nreturn err\n}\n", optsVar, strconv.Quote(defaultValue))
+		fmt.Fprintf(w, "if provided || len(values) > 0 {\n")
+		fmt.Fprintf(w, "list := make(%s, len(values))\n", g.useType(typ, typ.Name))
+		fmt.Fprintf(w, "for i, value := range values {\n")
+		g.convert(typ, optsVar, "list[i]")
+		fmt.Fprintf(w, "}\n%s = list\n}\n}\n", target)
+		return
+	}
+	fmt.Fprintf(w, "value, provided, err := gen.FieldValue(source, %s, %s)\nif err != nil {\nreturn err\n}\n", optsVar, strconv.Quote(defaultValue))
+	fmt.Fprintf(w, "if provided || value != \"\" {\n")
+	g.convert(typ, optsVar, target)
+	fmt.Fprintf(w, "}\n}\n")
+}
+
+// convert generates the code converting value to the type of the field,
+// and storing it in target
+func (g *generator) convert(typ *fieldType, optsVar, target string) {
+	w := &g.parse
+	var value string
+	switch typ.Kind {
+	case kindScanner:
+		// The scanner is allocated and filled before being stored, like
+		// with reflection
+		if typ.Pointer {
+			fmt.Fprintf(w, "v := new(%s)\n%s = v\n", g.useType(typ, typ.Base), target)
+			fmt.Fprintf(w, "if err := gen.ScanValue(%s, v, value); err != nil {\nreturn err\n}\n", optsVar)
+			return
+		}
+		fmt.Fprintf(w, "if err := gen.ScanValue(%s, &%s, value); err != nil {\nreturn err\n}\n", optsVar, target)
+		return
+	case kindBool:
+		fmt.Fprintf(w, "b, err := gen.BoolValue(%s, value)\nif err != nil {\nreturn err\n}\n", optsVar)
+		value = conversion(typ.Base, kindBool, "b")
+	case kindInt:
+		fmt.Fprintf(w, "n, err := gen.IntValue(%s, value)\nif err != nil {\nreturn err\n}\n", optsVar)
+		value = conversion(typ.Base, kindInt, "n")
+	case kindTime:
+		fmt.Fprintf(w, "t, err := gen.TimeValue(%s, value)\nif err != nil {\nreturn err\n}\n", optsVar)
+		value = "t"
+	default:
+		value = conversion(typ.Base, kindString, "value")
+	}
+
+	if typ.Pointer {
+		fmt.Fprintf(w, "v := %s\n%s = &v\n", value, target)
+		return
+	}
+	fmt.Fprintf(w, "%s = %s\n", target, value)
+}
+
+// conversion returns the expression converting a value to the provided
+// type. No conversions are made if the value already has the right type
+func conversion(typeName, kind, value string) string {
+	if typeName == kind {
+		return value
+	}
+	return typeName + "(" + value + ")"
+}
+
+// extractValue generates the code adding the value of a field to the
+// sources or the files
+func (g *generator) extractValue(typ *fieldType, field *structscan.Field, optsVar, target, location string) {
+	w := &g.extract
+	name, tagOptions := nameTag(field)
+	if name == "-" {
+		return
+	}
+	if name == "" {
+		name = field.Name
+	}
+	if location == "" {
+		location = "unknown"
+	}
+	quotedName := strconv.Quote(name)
+	source := "sources[" + strconv.Quote(location) + "]"
+
+	fmt.Fprintf(w, "\n// %s\n", target)
+	// The nil pointers are skipped
+	isPointer := typ.Pointer && !typ.Slice
+	if isPointer {
+		fmt.Fprintf(w, "if %s != nil {\n", target)
+	}
+	fmt.Fprintf(w, "if _, found := %s; !found {\n%s = url.Values{}\n}\n", source, source)
+
+	switch {
+	case typ.Kind == kindFile && typ.Slice:
+		fmt.Fprintf(w, "if %s != nil {\nmultipleFiles[%s] = %s\n}\n", target, quotedName, target)
+	case typ.Kind == kindFile:
+		fmt.Fprintf(w, "files[%s] = %s\n", quotedName, target)
+	case typ.Slice:
+		fmt.Fprintf(w, "if %s != nil {\n", target)
+		fmt.Fprintf(w, "if len(%s) == 0 {\n%s[%s] = []string{}\n}\n", target, source, quotedName)
+		fmt.Fprintf(w, "for _, v := range %s {\n%s.Add(%s, gen.FormatParam(%s, v))\n}\n}\n", target, source, quotedName, optsVar)
+	default:
+		// the zero values are not set when omitempty is used, unless
+		// Parse would replace them by the default value
+		if !hasString(tagOptions, "omitempty") || field.Tag.Get("default") != "" {
+			fmt.Fprintf(w, "%s.Set(%s, gen.FormatParam(%s, %s))\n", source, quotedName, optsVar, target)
+			break
+		}
+		value := target
+		if isPointer {
+			value = "*" + target
+		}
+		fmt.Fprintf(w, "if %s {\n", g.nonZero(typ, value))
+		fmt.Fprintf(w, "%s.Set(%s, gen.FormatParam(%s, %s))\n}\n", source, quotedName, optsVar, target)
+	}
+
+	if isPointer {
+		fmt.Fprintf(w, "}\n")
+	}
+}
+
+// nonZero returns the condition checking that a value is not the zero
+// value of its type
+func (g *generator) nonZero(typ *fieldType, value string) string {
+	switch typ.Kind {
+	case kindBool:
+		return value
+	case kindInt:
+		return value + " != 0"
+	case kindString:
+		return value + ` != ""`
+	case kindTime:
+		return "!" + value + ".IsZero()"
+	default:
+		// the structs containing slices or maps cannot be compared
+		return "!gen.IsZero(" + value + ")"
+	}
+}
+
+// useType imports the package of a type, and returns the expression
+// using the type
+func (g *generator) useType(typ *fieldType, expr string) string {
+	if typ.PackagePath != "" {
+		g.imports[typ.PackageName] = typ.PackagePath
+	}
+	return expr
+}
+
+// fieldType returns the type of a field of the provided struct
+func (g *generator) fieldType(s *structscan.Struct, name string) (*fieldType, error) {
+	typ := &fieldType{Name: name}
+	base := name
+	if strings.HasPrefix(base, "[]") {
+		typ.Slice = true
+		base = strings.TrimPrefix(base, "[]")
+	}
+	if strings.HasPrefix(base, "*") {
+		typ.Pointer = true
+		base = strings.TrimPrefix(base, "*")
+	}
+	typ.Base = base
+
+	// only the identifiers and the qualified identifiers are supported
+	for _, r := range base {
+		if !(r == '.' || r == '_' || r >= '0' && r <= '9' || r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z') {
+			return nil, fmt.Errorf("unsupported type %s", name)
+		}
+	}
+
+	if i := strings.Index(base, "."); i != -1 {
+		pkgName := base[:i]
+		path, found := s.Imports[pkgName]
+		if !found {
+			return nil, fmt.Errorf("unknown package %s", pkgName)
+		}
+		if path == formfilePath && base[i+1:] == "FormFile" {
+			if !typ.Pointer {
+				return nil, fmt.Errorf("the only accepted type for a file is *formfile.FormFile or []*formfile.FormFile, got %s", name)
+			}
+			typ.Kind = kindFile
+			return typ, nil
+		}
+		typ.PackageName = pkgName
+		typ.PackagePath = path
+		if path == "time" && base[i+1:] == "Time" {
+			typ.Kind = kindTime
+			return typ, nil
+		}
+		// We can't look at the packages that are not parsed, so the
+		// structs of other packages are expected to be Scanners.
+		// The generated code won't compile if they are not
+		typ.Kind = kindScanner
+		return typ, nil
+	}
+
+	switch base {
+	case kindString, kindBool, kindInt:
+		typ.Kind = base
+		return typ, nil
+	}
+	switch g.pkg.Types[base] {
+	case kindString, kindBool, kindInt:
+		typ.Kind = g.pkg.Types[base]
+		return typ, nil
+	}
+	if g.pkg.Lookup(base) != nil {
+		typ.Kind = kindScanner
+		return typ, nil
+	}
+	return nil, fmt.Errorf("unsupported type %s", name)
+}
+
+// checksumField returns the expression used to access the string field
+// containing the checksum of a file. The embedded structs are searched
+// as well
+func (g *generator) checksumField(s *structscan.Struct, name, path string) (value string, pointer bool, err error) {
+	value, pointer, found, err := g.findChecksumField(s, name, path, map[string]bool{})
+	if err == nil && !found {
+		err = fmt.Errorf("field %s does not exist", name)
+	}
+	return value, pointer, err
+}
+
+// findChecksumField looks for the string field having the given param
+// name in a struct and its embedded structs
+func (g *generator) findChecksumField(s *structscan.Struct, name, path string, visited map[string]bool) (value string, pointer, found bool, err error) {
+	if visited[s.Name] {
+		return "", false, false, fmt.Errorf("struct %s embeds itself", s.Name)
+	}
+	visited[s.Name] = true
+	defer delete(visited, s.Name)
+
+	for _, field := range s.Fields {
+		if field.Embedded {
+			embedded := g.pkg.EmbeddedStruct(field)
+			if embedded == nil {
+				continue
+			}
+			value, pointer, found, err = g.findChecksumField(embedded, name, path+field.Name+".", visited)
+			if err != nil || found {
+				return value, pointer, found, err
+			}
+			continue
+		}
+		opts, err := params.NewOptions(&field.Tag)
+		if err != nil {
+			return "", false, false, err
+		}
+		if opts.Ignore {
+			continue
+		}
+		if opts.Name == "" {
+			opts.Name = field.Name
+		}
+		if opts.Name != name {
+			continue
+		}
+		switch field.Type {
+		case "string":
+			return path + field.Name, false, true, nil
+		case "*string":
+			return path + field.Name, true, true, nil
+		}
+		return "", false, true, fmt.Errorf("field %s is not a string", field.Name)
+	}
+	return "", false, false, nil
+}
+
+// addOptions declares the Options of a field, and returns the name of
+// its variable. A number is added to the name if it's already used by
+// another field (A.BC and AB.C)
+func (g *generator) addOptions(s *structscan.Struct, field *structscan.Field) string {
+	key := s.Name + "." + field.Name
+	name, found := g.optionVars[key]
+	if !found {
+		base := "opts" + strings.ToUpper(s.Name[:1]) + s.Name[1:] + field.Name
+		name = base
+		for i := 2; g.options[name] != ""; i++ {
+			name = base + strconv.Itoa(i)
+		}
+
+		tag := "`" + string(field.Tag) + "`"
+		if strings.Contains(string(field.Tag), "`") {
+			tag = strconv.Quote(string(field.Tag))
+		}
+		g.options[name] = fmt.Sprintf("gen.NewOptions(%s, %s)", tag, strconv.Quote(field.Name))
+		g.optionVars[key] = name
+	}
+	if !hasString(g.fields, name) {
+		g.fields = append(g.fields, name)
+	}
+	return name
+}
+
+// nameTag returns the name and the options set in the tag used to
+// name the params
+func nameTag(field *structscan.Field) (name string, options []string) {
+	tag, found := field.Tag.Lookup("param")
+	if !found {
+		tag = field.Tag.Get("json")
+	}
+	if tag == "" {
+		return "", nil
+	}
+	parts := strings.Split(tag, ",")
+	return parts[0], parts[1:]
+}
+
+// hasString checks if a list contains the provided string
+func hasString(list []string, s string) bool {
+	for _, item := range list {
+		if item == s {
+			return true
+		}
+	}
+	return false
+}
+
+// sortedKeys returns the keys of a map, sorted
+func sortedKeys(m map[string]string) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
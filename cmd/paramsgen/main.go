@@ -0,0 +1,71 @@
+// Command paramsgen generates the ParseParams and ExtractParams methods of
+// the params structs annotated with a //params:generate directive.
+// Params.Parse and Params.Extract use those methods instead of
+// reflection when they are available.
+//
+// Usage:
+//
+//	paramsgen [-o file] [dir]
+//
+// The package in the current directory is used if no dir is provided,
+// and the code is written to params_gen.go in the directory of the
+// package. Use "-o -" to write the code to stdout.
+//
+// It's meant to be used with go generate:
+//
+//	//go:generate go run github.com/Nivl/go-params/cmd/paramsgen
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+
+	"github.com/Nivl/go-params/internal/structscan"
+)
+
+// defaultOutput contains the name of the file generated in the directory
+// of the package
+const defaultOutput = "params_gen.go"
+
+func main() {
+	if err := run(os.Args[1:], os.Stdout); err != nil {
+		fmt.Fprintln(os.Stderr, "paramsgen:", err)
+		os.Exit(1)
+	}
+}
+
+// run runs the command using the provided arguments. The code is written
+// to stdout if the output file is "-"
+func run(args []string, stdout io.Writer) error {
+	flags := flag.NewFlagSet("paramsgen", flag.ContinueOnError)
+	output := flags.String("o", "", "output file (default "+defaultOutput+" in the package directory)")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+
+	dir := "."
+	if flags.NArg() > 0 {
+		dir = flags.Arg(0)
+	}
+
+	pkg, err := structscan.Load(dir)
+	if err != nil {
+		return err
+	}
+	code, err := generate(pkg)
+	if err != nil {
+		return err
+	}
+
+	switch *output {
+	case "-":
+		_, err = stdout.Write(code)
+		return err
+	case "":
+		*output = filepath.Join(dir, defaultOutput)
+	}
+	return os.WriteFile(*output, code, 0o644)
+}
@@ -0,0 +1,176 @@
+package main
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// writePackage writes a package containing the provided code in a
+// temporary directory, and returns the directory
+func writePackage(t *testing.T, code string) string {
+	dir := t.TempDir()
+	err := os.WriteFile(filepath.Join(dir, "api.go"), []byte("package api\n\n"+code), 0o644)
+	require.NoError(t, err, "could not write the package")
+	return dir
+}
+
+func TestRunUpToDate(t *testing.T) {
+	t.Parallel()
+
+	// The generated code of paramsgentest is tested against the
+	// reflection, so we make sure it's up to date
+	dir := filepath.Join("..", "..", "internal", "paramsgentest")
+	out := &bytes.Buffer{}
+	err := run([]string{"-o", "-", dir}, out)
+	require.NoError(t, err, "run() should have succeed")
+
+	expected, err := os.ReadFile(filepath.Join(dir, defaultOutput))
+	require.NoError(t, err, "the generated file should exist")
+	assert.Equal(t, string(expected), out.String(), "the generated code is outdated, run go generate")
+}
+
+func TestRunOutputFile(t *testing.T) {
+	t.Parallel()
+
+	dir := writePackage(t, `
+// Params contains the params of an endpoint
+//
+//params:generate
+type Params struct {
+	Name string `+"`from:\"query\" json:\"name\"`"+`
+}
+
+// Other is not annotated
+type Other struct {
+	Name string `+"`from:\"query\" json:\"name\"`"+`
+}
+`)
+	err := run([]string{dir}, &bytes.Buffer{})
+	require.NoError(t, err, "run() should have succeed")
+
+	content, err := os.ReadFile(filepath.Join(dir, defaultOutput))
+	require.NoError(t, err, "the output file should exist")
+	code := string(content)
+	assert.Contains(t, code, "// Code generated by paramsgen. DO NOT EDIT.\n", "the file should be flagged as generated")
+	assert.Contains(t, code, "func (p *Params) ParseParams(", "ParseParams() should have been generated")
+	assert.Contains(t, code, "func (p *Params) ExtractParams(", "ExtractParams() should have been generated")
+	assert.NotContains(t, code, "*Other", "only the annotated structs should be generated")
+	assert.NotContains(t, code, "perror", "the unused packages should not be imported")
+}
+
+func TestRunChecksumInEmbeddedStruct(t *testing.T) {
+	t.Parallel()
+
+	dir := writePackage(t, `
+import "github.com/Nivl/go-params/formfile"
+
+// Checksum contains the checksum of the file
+type Checksum struct {
+	Sum *string `+"`from:\"form\" json:\"sum\"`"+`
+}
+
+//params:generate
+type Params struct {
+	File *formfile.FormFile `+"`from:\"file\" json:\"file\" checksum_field:\"sum\"`"+`
+	*Checksum
+}
+`)
+	out := &bytes.Buffer{}
+	err := run([]string{"-o", "-", dir}, out)
+	require.NoError(t, err, "run() should have succeed")
+	assert.Contains(t, out.String(), "optsParamsFile.ValidateChecksum(*p.Checksum.Sum,", "the checksum of the embedded struct should be used")
+}
+
+func TestRunOptionsNameCollision(t *testing.T) {
+	t.Parallel()
+
+	dir := writePackage(t, `
+//params:generate
+type A struct {
+	BC string `+"`from:\"query\" json:\"bc\" params:\"required\"`"+`
+}
+
+//params:generate
+type AB struct {
+	C string `+"`from:\"query\" json:\"c\"`"+`
+}
+`)
+	out := &bytes.Buffer{}
+	err := run([]string{"-o", "-", dir}, out)
+	require.NoError(t, err, "run() should have succeed")
+
+	code := out.String()
+	assert.Contains(t, code, "optsABC  = gen.NewOptions(`from:\"query\" json:\"bc\" params:\"required\"`, \"BC\")", "A.BC should use optsABC")
+	assert.Contains(t, code, "optsABC2 = gen.NewOptions(`from:\"query\" json:\"c\"`, \"C\")", "AB.C should use another variable")
+
+	parseA := code[strings.Index(code, "func (p *A) ParseParams("):strings.Index(code, "func (p *A) ExtractParams(")]
+	assert.Contains(t, parseA, "gen.FieldValue(source, optsABC,", "A.BC should be parsed using its own options")
+	assert.NotContains(t, parseA, "optsABC2", "A.BC should not use the options of AB.C")
+}
+
+func TestRunErrors(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		description string
+		fields      string
+	}{
+		{"missing source", "Name string `json:\"name\"`"},
+		{"unexported field", "name string `from:\"query\" json:\"name\"`"},
+		{"invalid tag", "Name string `from:\"query\" conflict:\"nope\"`"},
+		{"deprecated param", "Name string `from:\"query\" deprecated:\"use title\"`"},
+		{"unsupported type", "Price float64 `from:\"query\"`"},
+		{"pointer to slice", "Names *[]string `from:\"query\"`"},
+		{"unknown package", "Day date.Date `from:\"query\"`"},
+		{"file with wrong type", "File string `from:\"file\"`"},
+		{"file combined with sources", "File *formfile.FormFile `from:\"file,form\"`"},
+		{"file with wrong source", "File *formfile.FormFile `from:\"form\"`"},
+		{"checksum on multiple files", "Files []*formfile.FormFile `from:\"file\" checksum_field:\"sum\"`"},
+		{"unknown checksum field", "File *formfile.FormFile `from:\"file\" checksum_field:\"sum\"`"},
+		{"embedded struct of another package", "formfile.FormFile"},
+	}
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.description, func(t *testing.T) {
+			t.Parallel()
+
+			dir := writePackage(t, `
+import "github.com/Nivl/go-params/formfile"
+
+var _ formfile.FileHolder
+
+//params:generate
+type Params struct {
+	`+tc.fields+`
+}
+`)
+			err := run([]string{"-o", "-", dir}, &bytes.Buffer{})
+			assert.Error(t, err, "run() should have failed")
+		})
+	}
+
+	t.Run("no annotated structs", func(t *testing.T) {
+		t.Parallel()
+		dir := writePackage(t, "type Params struct{}\n")
+		err := run([]string{"-o", "-", dir}, &bytes.Buffer{})
+		assert.Error(t, err, "run() should have failed")
+	})
+
+	t.Run("unknown directory", func(t *testing.T) {
+		t.Parallel()
+		err := run([]string{"testdata/does-not-exist"}, &bytes.Buffer{})
+		assert.Error(t, err, "run() should have failed")
+	})
+
+	t.Run("unknown flag", func(t *testing.T) {
+		t.Parallel()
+		err := run([]string{"-unknown"}, &bytes.Buffer{})
+		assert.Error(t, err, "run() should have failed")
+	})
+}
@@ -0,0 +1,94 @@
+package main
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strconv"
+	"testing"
+
+	params "github.com/Nivl/go-params"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRun(t *testing.T) {
+	t.Parallel()
+
+	out := &bytes.Buffer{}
+	err := run([]string{"-naming", "camel", "testdata/api"}, out)
+	require.NoError(t, err, "run() should have succeed")
+	code := out.String()
+
+	expected := []string{
+		"// Pagination contains the params used to paginate a list\nexport interface Pagination {\n  page?: number;\n  perPage?: number;\n}\n",
+		"export const paginationRules: Record<string, FieldRules> = {\n  page: {\"minInt\":1,\"default\":\"1\"},\n  perPage: {\"maxInt\":100},\n};\n",
+		"export function validatePagination(params: Partial<Pagination>): ValidationError[] {\n  return validateParams(params as Record<string, unknown>, paginationRules);\n}\n",
+		"  sort?: \"name\" | \"created_at\";\n  filter: string;\n",
+		"  filter: {\"required\":true,\"maxLen\":10},\n",
+		"  \"X-Token\": string;\n  files?: File[];\n  tags?: (\"a\" | \"b\")[];\n",
+		"  \"X-Token\": {\"required\":true},\n",
+		"  files: {\"maxItems\":3,\"array\":true,\"file\":true},\n",
+		"  tags: {\"noEmptyItems\":true,\"trim\":true,\"enum\":[\"a\",\"b\"],\"default\":\"a\",\"array\":true},\n",
+		"  count?: number;\n  flag?: boolean;\n  labels?: string[];\n",
+		"  count: {\"minInt\":1,\"maxInt\":10},\n",
+		"  labels: {\"array\":true},\n",
+	}
+	for _, e := range expected {
+		assert.Contains(t, code, e, "the code is missing some data")
+	}
+	assert.NotContains(t, code, "secret", "ignored fields should not be generated")
+	assert.NotContains(t, code, "interface user", "only the params structs should be generated")
+}
+
+func TestRunMessages(t *testing.T) {
+	t.Parallel()
+
+	out := &bytes.Buffer{}
+	err := run([]string{"testdata/api"}, out)
+	require.NoError(t, err, "run() should have succeed")
+
+	// the validators need to return the same messages as the Go code
+	messages := map[string]string{
+		"missingParameter": params.ErrMsgMissingParameter,
+		"maxLen":           params.ErrMsgMaxLen,
+		"enum":             params.ErrMsgEnum,
+		"integerTooBig":    params.ErrMsgIntegerTooBig,
+		"arrayTooBig":      params.ErrMsgArrayTooBig,
+	}
+	for key, message := range messages {
+		assert.Contains(t, out.String(), "  "+key+": "+strconv.Quote(message)+",\n", "wrong message for %s", key)
+	}
+}
+
+func TestRunOutputFile(t *testing.T) {
+	t.Parallel()
+
+	output := filepath.Join(t.TempDir(), "params.ts")
+	err := run([]string{"-o", output, "testdata/api"}, &bytes.Buffer{})
+	require.NoError(t, err, "run() should have succeed")
+
+	content, err := os.ReadFile(output)
+	require.NoError(t, err, "the output file should exist")
+	assert.Contains(t, string(content), "export interface ListUsersParams {", "the file should contain the code")
+}
+
+func TestRunErrors(t *testing.T) {
+	testCases := []struct {
+		description string
+		args        []string
+	}{
+		{"unknown naming strategy", []string{"-naming", "pascal", "testdata/api"}},
+		{"unknown directory", []string{"testdata/does-not-exist"}},
+		{"unknown flag", []string{"-unknown"}},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.description, func(t *testing.T) {
+			t.Parallel()
+			err := run(tc.args, &bytes.Buffer{})
+			assert.Error(t, err, "run() should have failed")
+		})
+	}
+}
@@ -0,0 +1,205 @@
+package main
+
+import (
+	"text/template"
+)
+
+// tsTemplate is the template used to generate the TypeScript file
+var tsTemplate = template.Must(template.New("paramsts").Parse(`// Code generated by paramsts from the package {{.Package}}. DO NOT EDIT.
+
+export interface ValidationError {
+  field: string;
+  message: string;
+}
+
+export interface FieldRules {
+  required?: boolean;
+  noEmpty?: boolean;
+  noEmptyItems?: boolean;
+  trim?: boolean;
+  maxLen?: number;
+  uuid?: boolean;
+  slug?: boolean;
+  slugOrUuid?: boolean;
+  url?: boolean;
+  email?: boolean;
+  enum?: string[];
+  minInt?: number;
+  maxInt?: number;
+  minItems?: number;
+  maxItems?: number;
+  default?: string;
+  array?: boolean;
+  file?: boolean;
+}
+
+export const messages = {
+{{- range $key, $message := .Messages}}
+  {{$key}}: {{printf "%q" $message}},
+{{- end}}
+};
+
+const uuidPattern = /[a-f0-9]{8}-[a-f0-9]{4}-4[a-f0-9]{3}-[8|9|aA|bB][a-f0-9]{3}-[a-f0-9]{12}/;
+const emailPattern = /.+@.+\..+/;
+const integerPattern = /^[+-]?[0-9]+$/;
+
+function isValidSlug(text: string): boolean {
+  if (text === "" || /^[-_]/.test(text) || /[-_]$/.test(text)) {
+    return false;
+  }
+  return /^[a-z0-9_-]*$/.test(text);
+}
+
+function isValidUrl(text: string): boolean {
+  try {
+    return new URL(text).protocol.startsWith("http");
+  } catch {
+    return false;
+  }
+}
+
+function byteLength(text: string): number {
+  return new TextEncoder().encode(text).length;
+}
+
+// validateValue checks a single value, like Options.Validate
+export function validateValue(value: string, provided: boolean, isArrayItem: boolean, rules: FieldRules): string | null {
+  if (rules.maxLen !== undefined && rules.maxLen > 0 && byteLength(value) > rules.maxLen) {
+    return messages.maxLen;
+  }
+
+  if (isArrayItem) {
+    if (value === "" && rules.noEmptyItems) {
+      return messages.emptyItem;
+    }
+  } else {
+    if (value === "" && rules.required) {
+      return messages.missingParameter;
+    }
+    if (value === "" && rules.noEmpty && provided) {
+      return messages.emptyParameter;
+    }
+  }
+
+  if (value !== "") {
+    if (rules.uuid && !uuidPattern.test(value)) {
+      return messages.invalidUuid;
+    }
+    if (rules.slug && !isValidSlug(value)) {
+      return messages.invalidSlug;
+    }
+    if (rules.slugOrUuid && !isValidSlug(value) && !uuidPattern.test(value)) {
+      return messages.invalidSlugOrUuid;
+    }
+    if (rules.url && !isValidUrl(value)) {
+      return messages.invalidUrl;
+    }
+    if (rules.email && !emailPattern.test(value)) {
+      return messages.invalidEmail;
+    }
+    if (rules.enum !== undefined && !rules.enum.includes(value)) {
+      return messages.enum;
+    }
+    if (rules.minInt !== undefined || rules.maxInt !== undefined) {
+      if (!integerPattern.test(value)) {
+        return messages.invalidInteger;
+      }
+      const asInt = parseInt(value, 10);
+      if (rules.minInt !== undefined && asInt < rules.minInt) {
+        return messages.integerTooSmall;
+      }
+      if (rules.maxInt !== undefined && asInt > rules.maxInt) {
+        return messages.integerTooBig;
+      }
+    }
+  }
+  return null;
+}
+
+// validateField checks the value of a param, like Param.SetValue
+// and Param.SetFile
+export function validateField(value: unknown, rules: FieldRules): string | null {
+  const provided = value !== undefined && value !== null;
+  const transform = (v: unknown): string => (rules.trim ? String(v).trim() : String(v));
+
+  if (rules.file) {
+    const count = !provided ? 0 : Array.isArray(value) ? value.length : 1;
+    if (count === 0 && rules.required) {
+      return messages.missingParameter;
+    }
+    if (rules.array && rules.minItems !== undefined && count < rules.minItems) {
+      return messages.arrayTooSmall;
+    }
+    if (rules.array && rules.maxItems !== undefined && count > rules.maxItems) {
+      return messages.arrayTooBig;
+    }
+    return null;
+  }
+
+  if (rules.array) {
+    let values = provided ? (value as unknown[]).map(transform) : [];
+    if (values.length === 0 && rules.default !== undefined) {
+      values = rules.default.split(",");
+    }
+    if (values.length === 0 && rules.required) {
+      return messages.missingParameter;
+    }
+    if (values.length === 0 && provided && rules.noEmpty) {
+      return messages.emptyParameter;
+    }
+    if (rules.minItems !== undefined && values.length < rules.minItems) {
+      return messages.arrayTooSmall;
+    }
+    if (rules.maxItems !== undefined && values.length > rules.maxItems) {
+      return messages.arrayTooBig;
+    }
+    for (const v of values) {
+      const message = validateValue(v, provided, true, rules);
+      if (message !== null) {
+        return message;
+      }
+    }
+    return null;
+  }
+
+  let v = provided ? transform(value) : "";
+  if (v === "" && rules.default !== undefined) {
+    v = rules.default;
+  }
+  return validateValue(v, provided, false, rules);
+}
+
+// validateParams checks all the params of an object and returns the
+// first error of each invalid param
+export function validateParams(params: Record<string, unknown>, rules: Record<string, FieldRules>): ValidationError[] {
+  const errors: ValidationError[] = [];
+  for (const field of Object.keys(rules)) {
+    const message = validateField(params[field], rules[field]);
+    if (message !== null) {
+      errors.push({ field, message });
+    }
+  }
+  return errors;
+}
+{{- range .Interfaces}}
+{{$iface := .}}
+{{- range .Doc}}
+// {{.}}
+{{- end}}
+export interface {{.Name}} {
+{{- range .Fields}}
+  {{.Key}}{{if .Optional}}?{{end}}: {{.Type}};
+{{- end}}
+}
+
+export const {{.RulesName}}: Record<string, FieldRules> = {
+{{- range .Fields}}
+  {{.Key}}: {{.Rules}},
+{{- end}}
+};
+
+export function {{.ValidatorName}}(params: Partial<{{.Name}}>): ValidationError[] {
+  return validateParams(params as Record<string, unknown>, {{.RulesName}});
+}
+{{- end}}
+`))
@@ -0,0 +1,47 @@
+package api
+
+import (
+	"github.com/Nivl/go-params/formfile"
+)
+
+// Pagination contains the params used to paginate a list
+type Pagination struct {
+	Page    int `from:"query" json:"page" min_int:"1" default:"1"`
+	PerPage int `from:"query" alias:"perPage,size" max_int:"100"`
+}
+
+// ListUsersParams contains the params of the endpoint listing the users
+type ListUsersParams struct {
+	*Pagination
+	Sort   string `from:"query" json:"sort" enum:"name,created_at"`
+	Filter string `from:"query,form" json:"filter" params:"required" maxlen:"10"`
+	Secret string `from:"query" json:"-"`
+}
+
+// Count is an int parsed as a number
+type Count int
+
+// Flag is a bool parsed as a boolean
+type Flag bool
+
+// Labels is a list of strings
+type Labels []string
+
+// SettingsParams contains the params of the endpoint updating the settings
+type SettingsParams struct {
+	Count  Count  `from:"query" json:"count" min_int:"1" max_int:"10"`
+	Flag   *Flag  `from:"query" json:"flag"`
+	Labels Labels `from:"query" json:"labels"`
+}
+
+// user is not a params struct
+type user struct {
+	ID string `json:"id"`
+}
+
+// UploadParams contains the params of the endpoint uploading files
+type UploadParams struct {
+	Token string               `from:"header" json:"X-Token" params:"required"`
+	Files []*formfile.FormFile `from:"file" json:"files" max_items:"3"`
+	Tags  []string             `from:"form" json:"tags" enum:"a,b" params:"trim,no_empty_items" default:"a"`
+}
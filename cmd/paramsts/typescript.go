@@ -0,0 +1,213 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	params "github.com/Nivl/go-params"
+	"github.com/Nivl/go-params/internal/structscan"
+)
+
+// tsFile contains the data needed to generate a TypeScript file
+type tsFile struct {
+	Package    string
+	Messages   map[string]string
+	Interfaces []*tsInterface
+}
+
+// tsInterface represents a params struct converted to TypeScript
+type tsInterface struct {
+	Name          string
+	Doc           []string
+	RulesName     string
+	ValidatorName string
+	Fields        []*tsField
+}
+
+// tsField represents a param converted to TypeScript
+type tsField struct {
+	Key      string
+	Type     string
+	Optional bool
+	Rules    string
+}
+
+// tsRules contains the validation rules of a param. It's marshalled to
+// JSON to be used as a FieldRules object
+type tsRules struct {
+	Required     bool     `json:"required,omitempty"`
+	NoEmpty      bool     `json:"noEmpty,omitempty"`
+	NoEmptyItems bool     `json:"noEmptyItems,omitempty"`
+	Trim         bool     `json:"trim,omitempty"`
+	MaxLen       int      `json:"maxLen,omitempty"`
+	UUID         bool     `json:"uuid,omitempty"`
+	Slug         bool     `json:"slug,omitempty"`
+	SlugOrUUID   bool     `json:"slugOrUuid,omitempty"`
+	URL          bool     `json:"url,omitempty"`
+	Email        bool     `json:"email,omitempty"`
+	Enum         []string `json:"enum,omitempty"`
+	MinInt       *int     `json:"minInt,omitempty"`
+	MaxInt       *int     `json:"maxInt,omitempty"`
+	MinItems     *int     `json:"minItems,omitempty"`
+	MaxItems     *int     `json:"maxItems,omitempty"`
+	Default      *string  `json:"default,omitempty"`
+	Array        bool     `json:"array,omitempty"`
+	File         bool     `json:"file,omitempty"`
+}
+
+// tsIdentifier matches the keys that don't need to be quoted
+var tsIdentifier = regexp.MustCompile(`^[A-Za-z_$][A-Za-z0-9_$]*$`)
+
+// newTSFile converts all the params structs of a package to TypeScript
+func newTSFile(pkg *structscan.Package, strategy params.NamingStrategy) (*tsFile, error) {
+	file := &tsFile{
+		Package: pkg.Name,
+		Messages: map[string]string{
+			"missingParameter":  params.ErrMsgMissingParameter,
+			"emptyParameter":    params.ErrMsgEmptyParameter,
+			"emptyItem":         params.ErrMsgEmptyItem,
+			"maxLen":            params.ErrMsgMaxLen,
+			"invalidUuid":       params.ErrMsgInvalidUUID,
+			"invalidSlug":       params.ErrMsgInvalidSlug,
+			"invalidSlugOrUuid": params.ErrMsgInvalidSlugOrUUID,
+			"invalidUrl":        params.ErrMsgInvalidURL,
+			"invalidEmail":      params.ErrMsgInvalidEmail,
+			"enum":              params.ErrMsgEnum,
+			"invalidInteger":    params.ErrMsgInvalidInteger,
+			"integerTooSmall":   params.ErrMsgIntegerTooSmall,
+			"integerTooBig":     params.ErrMsgIntegerTooBig,
+			"arrayTooSmall":     params.ErrMsgArrayTooSmall,
+			"arrayTooBig":       params.ErrMsgArrayTooBig,
+		},
+	}
+
+	for _, s := range pkg.ParamsStructs() {
+		list, err := pkg.Params(s, strategy)
+		if err != nil {
+			return nil, fmt.Errorf("struct %s: %s", s.Name, err.Error())
+		}
+
+		iface := &tsInterface{
+			Name:          s.Name,
+			RulesName:     strings.ToLower(s.Name[:1]) + s.Name[1:] + "Rules",
+			ValidatorName: "validate" + strings.ToUpper(s.Name[:1]) + s.Name[1:],
+		}
+		if s.Doc != "" {
+			iface.Doc = strings.Split(s.Doc, "\n")
+		}
+		for _, param := range list {
+			field, err := newTSField(pkg, param)
+			if err != nil {
+				return nil, fmt.Errorf("struct %s: %s", s.Name, err.Error())
+			}
+			iface.Fields = append(iface.Fields, field)
+		}
+		file.Interfaces = append(file.Interfaces, iface)
+	}
+	return file, nil
+}
+
+// newTSField converts a param to TypeScript
+func newTSField(pkg *structscan.Package, param *structscan.Param) (*tsField, error) {
+	opts := param.Options
+	goType := strings.TrimPrefix(resolveType(pkg, param.Field.Type), "*")
+
+	rules := &tsRules{
+		Required:     opts.Required,
+		NoEmpty:      opts.NoEmpty,
+		NoEmptyItems: opts.NoEmptyItems,
+		Trim:         opts.Trim,
+		MaxLen:       opts.MaxLen,
+		UUID:         opts.ValidateUUID,
+		Slug:         opts.ValidateSlug,
+		SlugOrUUID:   opts.ValidateSlugOrUUID,
+		URL:          opts.ValidateURL,
+		Email:        opts.ValidateEmail,
+		Enum:         opts.AuthorizedValues,
+		MinInt:       opts.MinInt,
+		MaxInt:       opts.MaxInt,
+		MinItems:     opts.MinItems,
+		MaxItems:     opts.MaxItems,
+		Array:        strings.HasPrefix(goType, "[]"),
+		File:         strings.HasSuffix(goType, "formfile.FormFile"),
+	}
+	if param.Default != "" {
+		rules.Default = &param.Default
+	}
+	encodedRules, err := json.Marshal(rules)
+	if err != nil {
+		return nil, err
+	}
+
+	key := param.Name
+	if !tsIdentifier.MatchString(key) {
+		key = strconv.Quote(key)
+	}
+	return &tsField{
+		Key:      key,
+		Type:     tsType(goType, opts),
+		Optional: !opts.Required || param.Default != "",
+		Rules:    string(encodedRules),
+	}, nil
+}
+
+// resolveType replaces the types declared in the package by their
+// underlying type (type Count int is an int), to use the type Go parses
+func resolveType(pkg *structscan.Package, goType string) string {
+	prefix := ""
+	visited := map[string]bool{}
+	for {
+		switch {
+		case strings.HasPrefix(goType, "[]"):
+			prefix += "[]"
+			goType = goType[2:]
+		case strings.HasPrefix(goType, "*"):
+			prefix += "*"
+			goType = goType[1:]
+		default:
+			underlying, found := pkg.Types[goType]
+			// type List []List is valid
+			if !found || visited[goType] {
+				return prefix + goType
+			}
+			visited[goType] = true
+			goType = underlying
+		}
+	}
+}
+
+// tsType returns the TypeScript type of a Go type
+func tsType(goType string, opts *params.Options) string {
+	goType = strings.TrimPrefix(goType, "*")
+	if strings.HasPrefix(goType, "[]") {
+		itemType := tsType(goType[2:], opts)
+		if strings.Contains(itemType, " | ") {
+			itemType = "(" + itemType + ")"
+		}
+		return itemType + "[]"
+	}
+
+	switch goType {
+	case "formfile.FormFile":
+		return "File"
+	case "bool":
+		return "boolean"
+	case "int", "int8", "int16", "int32", "int64",
+		"uint", "uint8", "uint16", "uint32", "uint64",
+		"float32", "float64":
+		return "number"
+	case "string":
+		if len(opts.AuthorizedValues) > 0 {
+			values := make([]string, len(opts.AuthorizedValues))
+			for i, v := range opts.AuthorizedValues {
+				values[i] = strconv.Quote(v)
+			}
+			return strings.Join(values, " | ")
+		}
+	}
+	// strings and types implementing params.Scanner
+	return "string"
+}
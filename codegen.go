@@ -0,0 +1,36 @@
+package params
+
+import (
+	"net/url"
+
+	"github.com/Nivl/go-params/formfile"
+)
+
+// The code generated by cmd/paramsgen relies on the helpers of the gen
+// package, which are not meant to be used by anything else
+
+// Parser is implemented by the structs having a ParseParams method
+// generated by cmd/paramsgen. Parse uses it instead of reflection when
+// the Params uses its default configuration
+type Parser interface {
+	ParseParams(sources map[string]url.Values, fileHolder formfile.FileHolder) error
+}
+
+// Extractor is implemented by the structs having an ExtractParams method
+// generated by cmd/paramsgen. Extract uses it instead of reflection when
+// the Params uses its default configuration
+type Extractor interface {
+	ExtractParams() (sources map[string]url.Values, files map[string]*formfile.FormFile, multipleFiles map[string][]*formfile.FormFile, err error)
+}
+
+// usesGeneratedCode checks if the generated ParseParams and ExtractParams
+// methods can be used. They only support the default configuration
+func (p *Params) usesGeneratedCode() bool {
+	return !p.strict &&
+		!p.caseInsensitive &&
+		p.namingStrategy == nil &&
+		p.warningHook == nil &&
+		len(p.fileInspectors) == 0 &&
+		len(p.namedFileInspectors) == 0 &&
+		tagKey == defaultTagKey
+}
@@ -0,0 +1,105 @@
+package params
+
+import (
+	"crypto/md5" //nolint:gosec // used to identify files, not for security
+	"crypto/sha256"
+	"encoding/hex"
+	"hash"
+	"io"
+)
+
+// digestReader is an io.ReadSeeker that computes the digests of a file
+// while it's being read. Every byte is hashed once and in order, no
+// matter how many times the file is rewound, which allows the digests
+// to be computed while the file is being validated
+type digestReader struct {
+	io.ReadSeeker
+
+	sha256 hash.Hash
+	md5    hash.Hash
+
+	// pos contains the current offset in the file
+	pos int64
+	// hashed contains the number of bytes that have been hashed
+	hashed int64
+}
+
+// newDigestReader returns a reader computing the digests of the file
+// requested by the options. Both digests are computed if a checksum
+// needs to be verified since we cannot know which one will be needed.
+// The file is rewound if a digest is requested
+func (opts *Options) newDigestReader(file io.ReadSeeker) (*digestReader, error) {
+	r := &digestReader{ReadSeeker: file}
+	if opts.HashSHA256 || opts.ChecksumField != "" {
+		r.sha256 = sha256.New()
+	}
+	if opts.HashMD5 || opts.ChecksumField != "" {
+		r.md5 = md5.New()
+	}
+	if r.sha256 == nil && r.md5 == nil {
+		return r, nil
+	}
+
+	if _, err := r.Seek(0, io.SeekStart); err != nil {
+		return nil, err
+	}
+	return r, nil
+}
+
+// Read reads the file and hashes the bytes that have not been hashed yet
+func (r *digestReader) Read(p []byte) (int, error) {
+	n, err := r.ReadSeeker.Read(p)
+	// We only hash the bytes following the last byte hashed
+	if skip := r.hashed - r.pos; skip >= 0 && skip < int64(n) {
+		if r.sha256 != nil {
+			_, _ = r.sha256.Write(p[skip:n])
+		}
+		if r.md5 != nil {
+			_, _ = r.md5.Write(p[skip:n])
+		}
+		r.hashed = r.pos + int64(n)
+	}
+	r.pos += int64(n)
+	return n, err
+}
+
+// Seek moves the offset of the file
+func (r *digestReader) Seek(offset int64, whence int) (int64, error) {
+	pos, err := r.ReadSeeker.Seek(offset, whence)
+	if err == nil {
+		r.pos = pos
+	}
+	return pos, err
+}
+
+// Sums hashes the part of the file that has not been read yet, and
+// returns the hex encoded digests. The digests that were not requested
+// are empty. The file is rewound
+func (r *digestReader) Sums() (sha256Sum, md5Sum string, err error) {
+	if r.sha256 == nil && r.md5 == nil {
+		return "", "", nil
+	}
+
+	// Just for security, but it shouldn't be necessary
+	defer func() {
+		_, seekErr := r.Seek(0, io.SeekStart)
+		if err == nil {
+			err = seekErr
+		}
+	}()
+
+	if _, err = r.Seek(r.hashed, io.SeekStart); err != nil {
+		return "", "", err
+	}
+	if _, err = io.Copy(io.Discard, r); err != nil {
+		return "", "", err
+	}
+
+	if r.sha256 != nil {
+		sha256Sum = hex.EncodeToString(r.sha256.Sum(nil))
+	}
+	if r.md5 != nil {
+		md5Sum = hex.EncodeToString(r.md5.Sum(nil))
+	}
+	return sha256Sum, md5Sum, nil
+}
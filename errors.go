@@ -1,5 +1,17 @@
 package params
 
+import "errors"
+
+var (
+	// ErrNilTarget is returned when the struct to parse or extract is nil
+	ErrNilTarget = errors.New("params: the target is nil")
+
+	// ErrNotStructPointer is returned when the data to parse is not a
+	// pointer to a struct, or when the data to extract is not a struct
+	// (or a pointer to a struct)
+	ErrNotStructPointer = errors.New("params: the target is not a pointer to a struct")
+)
+
 const (
 	// ErrMsgMissingParameter represents the error message corresponding to
 	// a missing param
@@ -49,6 +61,10 @@ const (
 	// an invalid integer
 	ErrMsgInvalidInteger = "invalid integer"
 
+	// ErrMsgInvalidTime represents the error message corresponding to
+	// a time that is not in the RFC 3339 format
+	ErrMsgInvalidTime = "invalid time"
+
 	// ErrMsgIntegerTooBig represents the error message corresponding to
 	// an integer being too big
 	ErrMsgIntegerTooBig = "value too high"
@@ -65,6 +81,67 @@ const (
 	// a corrupted file
 	ErrMsgCorruptedFile = "file seems corrupted"
 
+	// ErrMsgInvalidSize represents the error message corresponding to
+	// an invalid size
+	ErrMsgInvalidSize = "invalid size"
+
+	// ErrMsgFileTooBig represents the error message corresponding to
+	// a file being too big
+	ErrMsgFileTooBig = "file too big"
+
+	// ErrMsgFileTooSmall represents the error message corresponding to
+	// a file being too small
+	ErrMsgFileTooSmall = "file too small"
+
+	// ErrMsgInvalidMime represents the error message corresponding to
+	// a file having a type that is not accepted
+	ErrMsgInvalidMime = "file type not allowed"
+
+	// ErrMsgInvalidExtension represents the error message corresponding to
+	// a file having an extension that is not accepted
+	ErrMsgInvalidExtension = "file extension not allowed"
+
+	// ErrMsgMimeMismatch represents the error message corresponding to
+	// a file having a content that doesn't match its extension or its
+	// declared content type
+	ErrMsgMimeMismatch = "file content does not match its type"
+
+	// ErrMsgInvalidHash represents the error message corresponding to
+	// an unsupported hash algorithm
+	ErrMsgInvalidHash = "unsupported hash"
+
+	// ErrMsgInvalidChecksum represents the error message corresponding to
+	// a checksum that is neither a MD5 or a SHA-256 digest
+	ErrMsgInvalidChecksum = "not a valid checksum"
+
+	// ErrMsgChecksumMismatch represents the error message corresponding to
+	// a file not matching its checksum
+	ErrMsgChecksumMismatch = "checksum mismatch"
+
+	// ErrMsgInvalidRatio represents the error message corresponding to
+	// an invalid ratio
+	ErrMsgInvalidRatio = "invalid ratio"
+
+	// ErrMsgImageTooNarrow represents the error message corresponding to
+	// an image not being wide enough
+	ErrMsgImageTooNarrow = "image too narrow"
+
+	// ErrMsgImageTooWide represents the error message corresponding to
+	// an image being too wide
+	ErrMsgImageTooWide = "image too wide"
+
+	// ErrMsgImageTooShort represents the error message corresponding to
+	// an image not being tall enough
+	ErrMsgImageTooShort = "image too short"
+
+	// ErrMsgImageTooTall represents the error message corresponding to
+	// an image being too tall
+	ErrMsgImageTooTall = "image too tall"
+
+	// ErrMsgWrongAspectRatio represents the error message corresponding to
+	// an image not having the expected aspect ratio
+	ErrMsgWrongAspectRatio = "wrong aspect ratio"
+
 	// ErrMsgArrayTooBig represents the error message corresponding to
 	// an array being too big
 	ErrMsgArrayTooBig = "too many elements"
@@ -76,4 +153,16 @@ const (
 	// ErrMsgEmptyItem represents the error message corresponding to
 	// an array containing an empty item
 	ErrMsgEmptyItem = "array cannot contain empty items"
+
+	// ErrMsgUnknownParameter represents the error message corresponding to
+	// a param that doesn't match any fields
+	ErrMsgUnknownParameter = "unknown parameter"
+
+	// ErrMsgInvalidConflict represents the error message corresponding to
+	// an unsupported conflict strategy
+	ErrMsgInvalidConflict = "unsupported conflict strategy"
+
+	// ErrMsgConflictingValues represents the error message corresponding to
+	// a param having different values in multiple sources
+	ErrMsgConflictingValues = "sources provide different values"
 )
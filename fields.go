@@ -0,0 +1,211 @@
+package params
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// FieldInfo contains the description of a param
+type FieldInfo struct {
+	// Name contains the name of the param in the payload
+	Name string
+
+	// GoName contains the name of the struct field
+	GoName string
+
+	// Path contains the names of the struct fields leading to the
+	// field, starting from the root struct. It contains the embedded
+	// structs, like []string{"Pagination", "Page"}
+	Path []string
+
+	// Sources contains the sources of the param, in order of precedence
+	Sources []string
+
+	// Type contains the Go type of the field
+	Type reflect.Type
+
+	// Options contains the options parsed from the tags
+	Options *Options
+
+	// Default contains the default value of the param
+	Default string
+}
+
+// Describe returns the description of all the params of T, including
+// the ones of its embedded structs. The ignored fields are skipped.
+// T needs to be a struct, ErrNotStructPointer is returned otherwise
+func Describe[T any]() ([]FieldInfo, error) {
+	return New(new(T)).Describe()
+}
+
+// Describe returns the description of all the params of the struct,
+// including the ones of its embedded structs. The ignored fields are
+// skipped, and the naming strategy is applied to the untagged fields
+func (p *Params) Describe() ([]FieldInfo, error) {
+	target, err := p.target(true)
+	if err != nil {
+		return nil, err
+	}
+	return DescribeStruct(reflectStruct{target.Type()}, p.namingStrategy)
+}
+
+// StructDecl represents the declaration of a struct. It allows the params
+// of a struct to be described without reflection (from its source code,
+// for example) using DescribeStruct
+type StructDecl interface {
+	// ID returns a value identifying the struct, used to detect structs
+	// embedding themselves
+	ID() string
+
+	// Fields returns the fields of the struct, in the order they are
+	// declared
+	Fields() []FieldDecl
+}
+
+// FieldDecl represents the declaration of a struct field
+type FieldDecl struct {
+	// Name contains the name of the field
+	Name string
+
+	// Tag contains the tag of the field
+	Tag reflect.StructTag
+
+	// Type contains the Go type of the field, nil if not available
+	Type reflect.Type
+
+	// Embedded contains the struct embedded by the field, nil if the
+	// field doesn't embed a struct
+	Embedded StructDecl
+}
+
+// DescribeStruct returns the description of all the params of a struct
+// declaration, including the ones of its embedded structs. The ignored
+// fields are skipped, and the naming strategy is applied to the untagged
+// fields. The rules are the same as the ones used by Describe
+func DescribeStruct(s StructDecl, strategy NamingStrategy) ([]FieldInfo, error) {
+	fields, err := describeStruct(s, strategy, nil, map[string]bool{})
+	if err != nil {
+		return nil, err
+	}
+
+	list := make([]FieldInfo, len(fields))
+	for i, field := range fields {
+		list[i] = *field
+	}
+	return list, nil
+}
+
+// describeFields returns the description of all the params of a struct
+// type, including the ones of its embedded structs
+func (p *Params) describeFields(structType reflect.Type) ([]*FieldInfo, error) {
+	return describeStruct(reflectStruct{structType}, p.namingStrategy, nil, map[string]bool{})
+}
+
+// describeStruct returns the description of all the params of a struct,
+// including the ones of its embedded structs. The ignored fields are
+// skipped. path contains the fields leading to the struct, and visited
+// the structs being described, to catch the structs embedding themselves
+func describeStruct(s StructDecl, strategy NamingStrategy, path []string, visited map[string]bool) ([]*FieldInfo, error) {
+	if visited[s.ID()] {
+		return nil, fmt.Errorf("struct %s embeds itself", s.ID())
+	}
+	visited[s.ID()] = true
+	defer delete(visited, s.ID())
+
+	fields := []*FieldInfo{}
+	for _, field := range s.Fields() {
+		tags := field.Tag
+
+		if field.Embedded != nil {
+			embeddedFields, err := describeStruct(field.Embedded, strategy, fieldPath(path, field.Name), visited)
+			if err != nil {
+				return nil, err
+			}
+			fields = append(fields, embeddedFields...)
+			continue
+		}
+
+		opts, err := NewOptions(&tags)
+		if err != nil {
+			return nil, fmt.Errorf("field %s: %s", field.Name, err.Error())
+		}
+		if opts.Ignore {
+			continue
+		}
+		if opts.Name == "" {
+			opts.Name = field.Name
+			if strategy != nil {
+				opts.Name = strategy(field.Name)
+			}
+		}
+
+		sources := []string{}
+		for _, source := range strings.Split(strings.ToLower(tags.Get("from")), ",") {
+			if source = strings.TrimSpace(source); source != "" {
+				sources = append(sources, source)
+			}
+		}
+		if len(sources) == 0 {
+			return nil, fmt.Errorf("no source set for field %s", field.Name)
+		}
+
+		fields = append(fields, &FieldInfo{
+			Name:    opts.Name,
+			GoName:  field.Name,
+			Path:    fieldPath(path, field.Name),
+			Sources: sources,
+			Type:    field.Type,
+			Options: opts,
+			Default: tags.Get("default"),
+		})
+	}
+	return fields, nil
+}
+
+// reflectStruct is the StructDecl of a struct type
+type reflectStruct struct {
+	t reflect.Type
+}
+
+// ID returns the package and the name of the type
+func (s reflectStruct) ID() string {
+	return s.t.PkgPath() + "." + s.t.String()
+}
+
+// Fields returns the fields of the struct type
+func (s reflectStruct) Fields() []FieldDecl {
+	fields := make([]FieldDecl, s.t.NumField())
+	for i := range fields {
+		info := s.t.Field(i)
+		fields[i] = FieldDecl{
+			Name: info.Name,
+			Tag:  info.Tag,
+			Type: info.Type,
+		}
+		if isEmbeddedStruct(info) {
+			embeddedType := info.Type
+			if embeddedType.Kind() == reflect.Ptr {
+				embeddedType = embeddedType.Elem()
+			}
+			fields[i].Embedded = reflectStruct{embeddedType}
+		}
+	}
+	return fields
+}
+
+// fieldPath returns a copy of the path with the provided field appended
+func fieldPath(path []string, field string) []string {
+	newPath := make([]string, len(path), len(path)+1)
+	copy(newPath, path)
+	return append(newPath, field)
+}
+
+// isFileType checks if a type is used to store files
+func isFileType(t reflect.Type) bool {
+	switch t.String() {
+	case "*formfile.FormFile", "[]*formfile.FormFile":
+		return true
+	}
+	return false
+}
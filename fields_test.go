@@ -0,0 +1,98 @@
+package params_test
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	params "github.com/Nivl/go-params"
+	"github.com/Nivl/go-params/formfile"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type describePagination struct {
+	Page int `from:"query" json:"page" min_int:"1" default:"1"`
+}
+
+type describeParams struct {
+	describePagination
+	ID       string             `from:"url" json:"id" params:"uuid,required"`
+	Title    *string            `from:"form,query" json:"title" maxlen:"100"`
+	Tags     []string           `from:"query" json:"tags" enum:"go,rust"`
+	Cover    *formfile.FormFile `from:"file" json:"cover"`
+	Ignored  string             `from:"form" json:"-"`
+	Untagged string             `from:"query"`
+}
+
+// RecursiveParams embeds itself
+type RecursiveParams struct {
+	*RecursiveParams
+	Name string `from:"query" json:"name"`
+}
+
+func TestDescribe(t *testing.T) {
+	t.Parallel()
+
+	fields, err := params.Describe[describeParams]()
+	require.NoError(t, err, "Describe() should have succeed")
+	require.Len(t, fields, 6, "wrong number of fields")
+
+	testCases := []struct {
+		name    string
+		goName  string
+		path    []string
+		sources []string
+		typ     reflect.Type
+	}{
+		{"page", "Page", []string{"describePagination", "Page"}, []string{"query"}, reflect.TypeOf(0)},
+		{"id", "ID", []string{"ID"}, []string{"url"}, reflect.TypeOf("")},
+		{"title", "Title", []string{"Title"}, []string{"form", "query"}, reflect.TypeOf((*string)(nil))},
+		{"tags", "Tags", []string{"Tags"}, []string{"query"}, reflect.TypeOf([]string{})},
+		{"cover", "Cover", []string{"Cover"}, []string{"file"}, reflect.TypeOf((*formfile.FormFile)(nil))},
+		{"Untagged", "Untagged", []string{"Untagged"}, []string{"query"}, reflect.TypeOf("")},
+	}
+	for i, tc := range testCases {
+		field := fields[i]
+		assert.Equal(t, tc.name, field.Name, "wrong name")
+		assert.Equal(t, tc.goName, field.GoName, "wrong Go name for %s", tc.name)
+		assert.Equal(t, tc.path, field.Path, "wrong path for %s", tc.name)
+		assert.Equal(t, tc.sources, field.Sources, "wrong sources for %s", tc.name)
+		assert.Equal(t, tc.typ, field.Type, "wrong type for %s", tc.name)
+		require.NotNil(t, field.Options, "the options of %s should have been parsed", tc.name)
+		assert.Equal(t, tc.name, field.Options.Name, "the options of %s should contain the name", tc.name)
+	}
+
+	assert.Equal(t, "1", fields[0].Default, "wrong default value")
+	assert.True(t, fields[1].Options.Required, "id should be required")
+	assert.True(t, fields[1].Options.ValidateUUID, "id should be a uuid")
+	assert.Equal(t, 100, fields[2].Options.MaxLen, "wrong maxlen")
+	assert.Equal(t, []string{"go", "rust"}, fields[3].Options.AuthorizedValues, "wrong enum")
+}
+
+func TestDescribeNamingStrategy(t *testing.T) {
+	t.Parallel()
+
+	p := params.New(&describeParams{})
+	p.SetNamingStrategy(params.SnakeCase)
+	fields, err := p.Describe()
+	require.NoError(t, err, "Describe() should have succeed")
+	require.Len(t, fields, 6, "wrong number of fields")
+	assert.Equal(t, "untagged", fields[5].Name, "the naming strategy should have been applied")
+}
+
+func TestDescribeErrors(t *testing.T) {
+	t.Parallel()
+
+	_, err := params.Describe[string]()
+	assert.True(t, errors.Is(err, params.ErrNotStructPointer), "Describe() should have failed")
+
+	type invalidParams struct {
+		Name string `json:"name"`
+	}
+	_, err = params.Describe[invalidParams]()
+	assert.Error(t, err, "Describe() should have failed on a field without sources")
+
+	_, err = params.Describe[RecursiveParams]()
+	assert.Error(t, err, "Describe() should have failed on a struct embedding itself")
+}
@@ -0,0 +1,56 @@
+package params
+
+import (
+	"fmt"
+	"reflect"
+	"time"
+)
+
+var (
+	timeType      = reflect.TypeOf(time.Time{})
+	formatterType = reflect.TypeOf((*Formatter)(nil)).Elem()
+)
+
+// FormatValue converts a value to the string used by Extract, which can
+// be read back by Parse:
+//   - Formatters use FormatString(), even with a pointer receiver
+//   - pointers are dereferenced, and nil pointers are empty strings
+//   - time.Time uses RFC 3339, in UTC
+//   - the other values use fmt
+func FormatValue(value interface{}) string {
+	return formatValue(reflect.ValueOf(value))
+}
+
+// formatValue converts a value to the string used by Extract
+func formatValue(value reflect.Value) string {
+	if !value.IsValid() {
+		return ""
+	}
+
+	// We check the pointer before dereferencing it since the Formatter
+	// may use a pointer receiver
+	if value.Kind() == reflect.Ptr {
+		if value.IsNil() {
+			return ""
+		}
+		if f, ok := value.Interface().(Formatter); ok {
+			return f.FormatString()
+		}
+		return formatValue(value.Elem())
+	}
+
+	if f, ok := value.Interface().(Formatter); ok {
+		return f.FormatString()
+	}
+	// We use a copy of the value to get access to the pointer receivers
+	if reflect.PtrTo(value.Type()).Implements(formatterType) {
+		ptr := reflect.New(value.Type())
+		ptr.Elem().Set(value)
+		return ptr.Interface().(Formatter).FormatString()
+	}
+
+	if value.Type() == timeType {
+		return value.Interface().(time.Time).UTC().Format(time.RFC3339Nano)
+	}
+	return fmt.Sprintf("%v", value.Interface())
+}
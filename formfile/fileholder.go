@@ -1,4 +1,4 @@
-//go:generate mockgen -destination mockformfile/fileholder.go -package mockformfile github.com/Nivl/go-params/formfile FileHolder
+//go:generate mockgen -destination mockformfile/fileholder.go -package mockformfile github.com/Nivl/go-params/formfile FileHolder,MultiFileHolder
 
 package formfile
 
@@ -8,3 +8,14 @@ import "mime/multipart"
 type FileHolder interface {
 	FormFile(key string) (multipart.File, *multipart.FileHeader, error)
 }
+
+// MultiFileHolder represents an interface to fetch all the files sent
+// under the same key
+type MultiFileHolder interface {
+	FileHolder
+
+	// FormFiles returns all the files attached to the key, and their headers
+	// in the same order. http.ErrMissingFile is returned if no files
+	// are found
+	FormFiles(key string) ([]multipart.File, []*multipart.FileHeader, error)
+}
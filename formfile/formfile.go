@@ -9,4 +9,14 @@ type FormFile struct {
 	File   multipart.File
 	Header *multipart.FileHeader
 	Mime   string
+
+	// Width and Height contain the dimensions of the file when it's an
+	// image. They are set to 0 for any other type of files
+	Width  int
+	Height int
+
+	// SHA256 and MD5 contain the hex encoded digests of the file. They are
+	// only computed when requested using the hash or checksum_field tags
+	SHA256 string
+	MD5    string
 }
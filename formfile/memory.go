@@ -0,0 +1,104 @@
+package formfile
+
+import (
+	"bytes"
+	"mime/multipart"
+	"net/http"
+	"net/textproto"
+)
+
+// defaultContentType is the content type used when none is provided. Same
+// value as the one used by multipart.Writer.CreateFormFile()
+const defaultContentType = "application/octet-stream"
+
+// memoryFile is a multipart.File stored in memory
+type memoryFile struct {
+	*bytes.Reader
+}
+
+// Close implements the io.Closer interface
+func (f *memoryFile) Close() error {
+	return nil
+}
+
+// memoryEntry represents a file stored by a MemoryHolder
+type memoryEntry struct {
+	filename    string
+	contentType string
+	content     []byte
+}
+
+// MemoryHolder is a MultiFileHolder that serves files stored in memory.
+// It can be used in tests, or by any non-HTTP callers
+type MemoryHolder struct {
+	files map[string][]*memoryEntry
+}
+
+// NewMemoryHolder creates a new MemoryHolder containing one file per key.
+// The key is used as filename
+func NewMemoryHolder(files map[string][]byte) *MemoryHolder {
+	h := &MemoryHolder{
+		files: map[string][]*memoryEntry{},
+	}
+	for key, content := range files {
+		h.Add(key, key, "", content)
+	}
+	return h
+}
+
+// Add adds a file to the given key. Adding multiple files to the same key
+// is allowed. application/octet-stream is used if no content type is provided
+func (h *MemoryHolder) Add(key, filename, contentType string, content []byte) {
+	if h.files == nil {
+		h.files = map[string][]*memoryEntry{}
+	}
+	if contentType == "" {
+		contentType = defaultContentType
+	}
+
+	h.files[key] = append(h.files[key], &memoryEntry{
+		filename:    filename,
+		contentType: contentType,
+		content:     content,
+	})
+}
+
+// FormFile returns the first file attached to the provided key.
+// http.ErrMissingFile is returned if there are no files
+func (h *MemoryHolder) FormFile(key string) (multipart.File, *multipart.FileHeader, error) {
+	entries := h.files[key]
+	if len(entries) == 0 {
+		return nil, nil, http.ErrMissingFile
+	}
+	file, header := entries[0].open()
+	return file, header, nil
+}
+
+// FormFiles returns all the files attached to the provided key.
+// http.ErrMissingFile is returned if there are no files
+func (h *MemoryHolder) FormFiles(key string) ([]multipart.File, []*multipart.FileHeader, error) {
+	entries := h.files[key]
+	if len(entries) == 0 {
+		return nil, nil, http.ErrMissingFile
+	}
+
+	files := make([]multipart.File, len(entries))
+	headers := make([]*multipart.FileHeader, len(entries))
+	for i, entry := range entries {
+		files[i], headers[i] = entry.open()
+	}
+	return files, headers, nil
+}
+
+// open returns a new reader on the file and its header. Every call
+// returns a new reader so the files can be read multiple times
+func (e *memoryEntry) open() (multipart.File, *multipart.FileHeader) {
+	header := &multipart.FileHeader{
+		Filename: e.filename,
+		Header:   textproto.MIMEHeader{},
+		Size:     int64(len(e.content)),
+	}
+	header.Header.Set("Content-Type", e.contentType)
+
+	return &memoryFile{Reader: bytes.NewReader(e.content)}, header
+}
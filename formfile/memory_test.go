@@ -0,0 +1,70 @@
+package formfile_test
+
+import (
+	"io/ioutil"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/Nivl/go-params/formfile"
+)
+
+func TestMemoryHolderFormFile(t *testing.T) {
+	t.Parallel()
+
+	holder := formfile.NewMemoryHolder(map[string][]byte{
+		"file": []byte("content"),
+	})
+
+	t.Run("existing key", func(t *testing.T) {
+		// We read the file twice to make sure it can be read multiple times
+		for i := 0; i < 2; i++ {
+			file, header, err := holder.FormFile("file")
+			require.NoError(t, err, "FormFile() should not have failed")
+			assert.Equal(t, "file", header.Filename, "Wrong filename")
+			assert.Equal(t, int64(7), header.Size, "Wrong size")
+			assert.Equal(t, "application/octet-stream", header.Header.Get("Content-Type"), "Wrong content type")
+
+			content, err := ioutil.ReadAll(file)
+			require.NoError(t, err, "ReadAll() should not have failed")
+			assert.Equal(t, "content", string(content), "Wrong content")
+			assert.NoError(t, file.Close(), "Close() should not have failed")
+		}
+	})
+
+	t.Run("unexisting key", func(t *testing.T) {
+		_, _, err := holder.FormFile("nope")
+		assert.Equal(t, http.ErrMissingFile, err, "FormFile() should have returned ErrMissingFile")
+	})
+}
+
+func TestMemoryHolderFormFiles(t *testing.T) {
+	t.Parallel()
+
+	holder := &formfile.MemoryHolder{}
+	holder.Add("files", "first.txt", "text/plain", []byte("first"))
+	holder.Add("files", "second.txt", "", []byte("second"))
+
+	t.Run("existing key", func(t *testing.T) {
+		files, headers, err := holder.FormFiles("files")
+		require.NoError(t, err, "FormFiles() should not have failed")
+		require.Len(t, files, 2, "FormFiles() should have returned 2 files")
+		require.Len(t, headers, 2, "FormFiles() should have returned 2 headers")
+
+		assert.Equal(t, "first.txt", headers[0].Filename, "Wrong filename")
+		assert.Equal(t, "text/plain", headers[0].Header.Get("Content-Type"), "Wrong content type")
+		assert.Equal(t, "second.txt", headers[1].Filename, "Wrong filename")
+		assert.Equal(t, "application/octet-stream", headers[1].Header.Get("Content-Type"), "Wrong content type")
+
+		content, err := ioutil.ReadAll(files[1])
+		require.NoError(t, err, "ReadAll() should not have failed")
+		assert.Equal(t, "second", string(content), "Wrong content")
+	})
+
+	t.Run("unexisting key", func(t *testing.T) {
+		_, _, err := holder.FormFiles("nope")
+		assert.Equal(t, http.ErrMissingFile, err, "FormFiles() should have returned ErrMissingFile")
+	})
+}
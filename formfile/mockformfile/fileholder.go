@@ -1,5 +1,5 @@
 // Code generated by MockGen. DO NOT EDIT.
-// Source: github.com/Nivl/go-params/formfile (interfaces: FileHolder)
+// Source: github.com/Nivl/go-params/formfile (interfaces: FileHolder,MultiFileHolder)
 
 // Package mockformfile is a generated GoMock package.
 package mockformfile
@@ -46,3 +46,54 @@ func (m *MockFileHolder) FormFile(arg0 string) (multipart.File, *multipart.FileH
 func (mr *MockFileHolderMockRecorder) FormFile(arg0 interface{}) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FormFile", reflect.TypeOf((*MockFileHolder)(nil).FormFile), arg0)
 }
+
+// MockMultiFileHolder is a mock of MultiFileHolder interface
+type MockMultiFileHolder struct {
+	ctrl     *gomock.Controller
+	recorder *MockMultiFileHolderMockRecorder
+}
+
+// MockMultiFileHolderMockRecorder is the mock recorder for MockMultiFileHolder
+type MockMultiFileHolderMockRecorder struct {
+	mock *MockMultiFileHolder
+}
+
+// NewMockMultiFileHolder creates a new mock instance
+func NewMockMultiFileHolder(ctrl *gomock.Controller) *MockMultiFileHolder {
+	mock := &MockMultiFileHolder{ctrl: ctrl}
+	mock.recorder = &MockMultiFileHolderMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use
+func (m *MockMultiFileHolder) EXPECT() *MockMultiFileHolderMockRecorder {
+	return m.recorder
+}
+
+// FormFile mocks base method
+func (m *MockMultiFileHolder) FormFile(arg0 string) (multipart.File, *multipart.FileHeader, error) {
+	ret := m.ctrl.Call(m, "FormFile", arg0)
+	ret0, _ := ret[0].(multipart.File)
+	ret1, _ := ret[1].(*multipart.FileHeader)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// FormFile indicates an expected call of FormFile
+func (mr *MockMultiFileHolderMockRecorder) FormFile(arg0 interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FormFile", reflect.TypeOf((*MockMultiFileHolder)(nil).FormFile), arg0)
+}
+
+// FormFiles mocks base method
+func (m *MockMultiFileHolder) FormFiles(arg0 string) ([]multipart.File, []*multipart.FileHeader, error) {
+	ret := m.ctrl.Call(m, "FormFiles", arg0)
+	ret0, _ := ret[0].([]multipart.File)
+	ret1, _ := ret[1].([]*multipart.FileHeader)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// FormFiles indicates an expected call of FormFiles
+func (mr *MockMultiFileHolderMockRecorder) FormFiles(arg0 interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FormFiles", reflect.TypeOf((*MockMultiFileHolder)(nil).FormFiles), arg0)
+}
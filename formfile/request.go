@@ -0,0 +1,52 @@
+package formfile
+
+import (
+	"mime/multipart"
+	"net/http"
+)
+
+// defaultMaxMemory is the amount of memory used to parse a multipart form
+// when it has not been parsed yet. Same value as the one used by net/http
+const defaultMaxMemory = 32 << 20 // 32 MB
+
+// RequestHolder is a MultiFileHolder that fetches the files from an
+// http.Request
+type RequestHolder struct {
+	*http.Request
+}
+
+// NewRequestHolder creates a new RequestHolder from an http.Request
+func NewRequestHolder(r *http.Request) *RequestHolder {
+	return &RequestHolder{
+		Request: r,
+	}
+}
+
+// FormFiles returns all the files attached to the provided key.
+// The multipart form will be parsed if needed
+func (r *RequestHolder) FormFiles(key string) ([]multipart.File, []*multipart.FileHeader, error) {
+	if r.MultipartForm == nil {
+		if err := r.ParseMultipartForm(defaultMaxMemory); err != nil {
+			return nil, nil, err
+		}
+	}
+
+	if r.MultipartForm == nil || len(r.MultipartForm.File[key]) == 0 {
+		return nil, nil, http.ErrMissingFile
+	}
+
+	headers := r.MultipartForm.File[key]
+	files := make([]multipart.File, len(headers))
+	for i, header := range headers {
+		f, err := header.Open()
+		if err != nil {
+			// we don't want to leak the files that have already been opened
+			for _, opened := range files[:i] {
+				_ = opened.Close()
+			}
+			return nil, nil, err
+		}
+		files[i] = f
+	}
+	return files, headers, nil
+}
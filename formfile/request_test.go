@@ -0,0 +1,53 @@
+package formfile_test
+
+import (
+	"bytes"
+	"io/ioutil"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/Nivl/go-params/formfile"
+)
+
+func TestRequestHolderFormFiles(t *testing.T) {
+	t.Parallel()
+
+	// build a multipart request containing 2 files under the same key
+	body := &bytes.Buffer{}
+	w := multipart.NewWriter(body)
+	for _, content := range []string{"first", "second"} {
+		part, err := w.CreateFormFile("files", content+".txt")
+		require.NoError(t, err, "CreateFormFile() should not have failed")
+		_, err = part.Write([]byte(content))
+		require.NoError(t, err, "Write() should not have failed")
+	}
+	require.NoError(t, w.Close(), "Close() should not have failed")
+
+	req := httptest.NewRequest(http.MethodPost, "/", body)
+	req.Header.Set("Content-Type", w.FormDataContentType())
+	holder := formfile.NewRequestHolder(req)
+
+	t.Run("existing key", func(t *testing.T) {
+		files, headers, err := holder.FormFiles("files")
+		require.NoError(t, err, "FormFiles() should not have failed")
+		require.Len(t, files, 2, "FormFiles() should have returned 2 files")
+		require.Len(t, headers, 2, "FormFiles() should have returned 2 headers")
+
+		for i, expected := range []string{"first", "second"} {
+			assert.Equal(t, expected+".txt", headers[i].Filename, "Wrong filename")
+			content, err := ioutil.ReadAll(files[i])
+			require.NoError(t, err, "ReadAll() should not have failed")
+			assert.Equal(t, expected, string(content), "Wrong content")
+		}
+	})
+
+	t.Run("unexisting key", func(t *testing.T) {
+		_, _, err := holder.FormFiles("nope")
+		assert.Equal(t, http.ErrMissingFile, err, "FormFiles() should have returned ErrMissingFile")
+	})
+}
@@ -1,13 +1,14 @@
 package testformfile
 
 import (
+	"io"
 	"mime/multipart"
+	"net/http"
 	"os"
 	"path"
 	"testing"
 
 	"github.com/Nivl/go-params/formfile"
-	"github.com/Nivl/go-types/filetype"
 )
 
 // NewMultipartData is a helper to generate multipart data that can be returned
@@ -40,10 +41,16 @@ func NewMultipartData(t *testing.T, cwd, filename string) (header *multipart.Fil
 func NewFormFile(t *testing.T, cwd, filename string) *formfile.FormFile {
 	header, f := NewMultipartData(t, cwd, filename)
 
-	mime, err := filetype.MimeType(f)
-	if err != nil {
+	// We only sniff the bytes read to get the same mime type as Parse
+	buf := make([]byte, 512)
+	n, err := io.ReadFull(f, buf)
+	if err != nil && err != io.ErrUnexpectedEOF {
+		t.Fatal(err)
+	}
+	if _, err := f.Seek(0, io.SeekStart); err != nil {
 		t.Fatal(err)
 	}
+	mime := http.DetectContentType(buf[:n])
 
 	return &formfile.FormFile{
 		File:   f,
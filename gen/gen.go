@@ -0,0 +1,183 @@
+// Package gen contains the helpers used by the code generated by
+// cmd/paramsgen. They are not meant to be used directly, and may change
+// along with the generator.
+package gen
+
+import (
+	"fmt"
+	"net/url"
+	"reflect"
+	"strconv"
+	"time"
+
+	params "github.com/Nivl/go-params"
+	"github.com/Nivl/go-params/formfile"
+	"github.com/Nivl/go-params/perror"
+)
+
+// Options contains the options of a field, parsed from its tag
+type Options struct {
+	*params.Options
+
+	tag       reflect.StructTag
+	fieldName string
+	err       error
+}
+
+// NewOptions parses the tag of a field. fieldName is used as name if the
+// tag doesn't set one. An invalid tag is reported by Check
+func NewOptions(tag reflect.StructTag, fieldName string) *Options {
+	o := &Options{
+		tag:       tag,
+		fieldName: fieldName,
+	}
+	o.Options, o.err = params.NewOptions(&tag)
+	if o.err == nil && o.Name == "" {
+		o.Name = fieldName
+	}
+	return o
+}
+
+// Check returns the error of the first options having an invalid tag.
+// The other helpers cannot be used with invalid options
+func Check(opts ...*Options) error {
+	for _, o := range opts {
+		if o.err != nil {
+			return o.err
+		}
+	}
+	return nil
+}
+
+// SelectSource returns the first source, in the provided order, that
+// contains the param. The first source is returned if none of them
+// contain the param
+func SelectSource(sources map[string]url.Values, opts *Options, locations ...string) (url.Values, error) {
+	var selected url.Values
+	var selectedKey string
+	for _, location := range locations {
+		source, found := sources[location]
+		if !found {
+			return nil, fmt.Errorf("source %s for field %s does not exist", location, opts.Name)
+		}
+
+		key, provided := opts.LookupKey(source)
+		if !provided {
+			continue
+		}
+		if selected == nil {
+			selected = source
+			selectedKey = key
+			continue
+		}
+		if err := opts.ValidateConflict(selected[selectedKey], source[key]); err != nil {
+			return nil, err
+		}
+	}
+
+	if selected == nil {
+		return sources[locations[0]], nil
+	}
+	return selected, nil
+}
+
+// FieldValue returns the value of a param from the source, once the
+// transformations and the default value applied, and makes sure it
+// passes the options
+func FieldValue(source url.Values, opts *Options, defaultValue string) (value string, provided bool, err error) {
+	key, provided := opts.LookupKey(source)
+	value, err = opts.ProcessValue(source.Get(key), provided, defaultValue)
+	return value, provided, err
+}
+
+// FieldValues returns the values of an array param from the source, once
+// the transformations and the default value applied, and makes sure they
+// pass the options
+func FieldValues(source url.Values, opts *Options, defaultValue string) (values []string, provided bool, err error) {
+	key, provided := opts.LookupKey(source)
+	values, err = opts.ProcessValues(source[key], provided, defaultValue)
+	return values, provided, err
+}
+
+// BoolValue converts the value of a param to a boolean
+func BoolValue(opts *Options, value string) (bool, error) {
+	v, err := strconv.ParseBool(value)
+	if err != nil {
+		return false, perror.New(opts.Name, params.ErrMsgInvalidBoolean)
+	}
+	return v, nil
+}
+
+// IntValue converts the value of a param to an integer
+func IntValue(opts *Options, value string) (int, error) {
+	v, err := strconv.ParseInt(value, 10, 64)
+	if err != nil {
+		return 0, perror.New(opts.Name, params.ErrMsgInvalidInteger)
+	}
+	return int(v), nil
+}
+
+// TimeValue converts the value of a param to a time.Time. The value needs
+// to use the RFC 3339 format, and the time is normalized to UTC
+func TimeValue(opts *Options, value string) (time.Time, error) {
+	v, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		return time.Time{}, perror.New(opts.Name, params.ErrMsgInvalidTime)
+	}
+	return v.UTC(), nil
+}
+
+// ScanValue fills a Scanner using the value of a param
+func ScanValue(opts *Options, scanner params.Scanner, value string) error {
+	if err := scanner.ScanString(value); err != nil {
+		return perror.New(opts.Name, err.Error())
+	}
+	return nil
+}
+
+// FormatParam converts the value of a param to the string used by
+// Extract, once the transformations applied
+func FormatParam(opts *Options, value interface{}) string {
+	return opts.ApplyTransformations(params.FormatValue(value))
+}
+
+// IsZero checks if a value is the zero value of its type. Unlike ==, it
+// works with the structs containing slices or maps
+func IsZero(value interface{}) bool {
+	v := reflect.ValueOf(value)
+	return !v.IsValid() || v.IsZero()
+}
+
+// FormFile returns the file of a param. A nil file is returned if the
+// file is missing and not required
+func FormFile(fileHolder formfile.FileHolder, opts *Options) (*formfile.FormFile, error) {
+	var ff *formfile.FormFile
+	err := opts.setFile(fileHolder, &ff)
+	return ff, err
+}
+
+// FormFiles returns the files of a param. The file holder needs to
+// be a formfile.MultiFileHolder
+func FormFiles(fileHolder formfile.FileHolder, opts *Options) ([]*formfile.FormFile, error) {
+	var files []*formfile.FormFile
+	err := opts.setFile(fileHolder, &files)
+	return files, err
+}
+
+// setFile uses params.Param to set the file(s) of the param in target,
+// which needs to be a pointer to a *formfile.FormFile or to a
+// []*formfile.FormFile
+func (opts *Options) setFile(fileHolder formfile.FileHolder, target interface{}) error {
+	value := reflect.ValueOf(target).Elem()
+	info := &reflect.StructField{
+		Name: opts.fieldName,
+		Type: value.Type(),
+		Tag:  opts.tag,
+	}
+	p := &params.Param{
+		Value: &value,
+		Info:  info,
+		Tags:  &info.Tag,
+	}
+	return p.SetFile(fileHolder)
+}
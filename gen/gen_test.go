@@ -0,0 +1,132 @@
+package gen_test
+
+import (
+	"net/url"
+	"reflect"
+	"testing"
+
+	params "github.com/Nivl/go-params"
+	"github.com/Nivl/go-params/formfile"
+	"github.com/Nivl/go-params/gen"
+	"github.com/Nivl/go-params/perror"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewOptions(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		description  string
+		tag          reflect.StructTag
+		expectedName string
+	}{
+		{"name from the tag", `from:"query" json:"per_page"`, "per_page"},
+		{"name from the field", `from:"query"`, "PerPage"},
+	}
+
+	for i, tc := range testCases {
+		tc := tc
+		i := i
+		t.Run(tc.description, func(t *testing.T) {
+			t.Parallel()
+			opts := gen.NewOptions(tc.tag, "PerPage")
+			require.NoError(t, gen.Check(opts), "Check() should have succeed for test %d", i)
+			assert.Equal(t, tc.expectedName, opts.Name, "invalid name for test %d", i)
+		})
+	}
+}
+
+func TestCheckInvalidTag(t *testing.T) {
+	t.Parallel()
+
+	tag := reflect.StructTag(`from:"query" conflict:"nope"`)
+	valid := gen.NewOptions(`from:"query" json:"name"`, "Name")
+	var invalid *gen.Options
+	require.NotPanics(t, func() {
+		invalid = gen.NewOptions(tag, "Name")
+	}, "an invalid tag should not panic")
+
+	_, expectedErr := params.NewOptions(&tag)
+	require.Error(t, expectedErr, "the tag should be invalid")
+	err := gen.Check(valid, invalid)
+	require.Error(t, err, "Check() should have failed")
+	assert.Equal(t, expectedErr.Error(), err.Error(), "Check() should return the error of the tag")
+}
+
+func TestSelectSource(t *testing.T) {
+	t.Parallel()
+
+	sources := map[string]url.Values{
+		"query": {},
+		"form":  {"q": []string{"form value"}},
+	}
+	opts := gen.NewOptions(`from:"query,form" json:"q"`, "Search")
+	source, err := gen.SelectSource(sources, opts, "query", "form")
+	require.NoError(t, err, "SelectSource() should have succeed")
+	assert.Equal(t, "form value", source.Get("q"), "the form should have been selected")
+
+	conflict := gen.NewOptions(`from:"query,form" json:"q" conflict:"error"`, "Search")
+	sources["query"].Set("q", "query value")
+	_, err = gen.SelectSource(sources, conflict, "query", "form")
+	require.Error(t, err, "SelectSource() should have failed")
+	e, ok := err.(perror.Error)
+	require.True(t, ok, "the error should be a perror.Error")
+	assert.Equal(t, "q", e.Field(), "invalid field")
+	assert.Equal(t, params.ErrMsgConflictingValues, e.Error(), "invalid error")
+}
+
+func TestFormFile(t *testing.T) {
+	t.Parallel()
+
+	holder := formfile.NewMemoryHolder(map[string][]byte{
+		"picture": []byte("a short note\n"),
+	})
+
+	opts := gen.NewOptions(`from:"file" json:"picture"`, "Picture")
+	ff, err := gen.FormFile(holder, opts)
+	require.NoError(t, err, "FormFile() should have succeed")
+	require.NotNil(t, ff, "the file should have been returned")
+	assert.Equal(t, "text/plain; charset=utf-8", ff.Mime, "invalid mime type")
+
+	missing := gen.NewOptions(`from:"file" json:"avatar"`, "Avatar")
+	ff, err = gen.FormFile(holder, missing)
+	require.NoError(t, err, "FormFile() should have succeed for a missing file")
+	assert.Nil(t, ff, "no file should have been returned")
+
+	required := gen.NewOptions(`from:"file" json:"avatar" params:"required"`, "Avatar")
+	_, err = gen.FormFile(holder, required)
+	require.Error(t, err, "FormFile() should have failed for a missing required file")
+
+	files, err := gen.FormFiles(holder, gen.NewOptions(`from:"file" json:"picture"`, "Pictures"))
+	require.NoError(t, err, "FormFiles() should have succeed")
+	assert.Len(t, files, 1, "the file should have been returned")
+}
+
+func TestIsZero(t *testing.T) {
+	t.Parallel()
+
+	type list struct {
+		Values []string
+	}
+
+	testCases := []struct {
+		description string
+		value       interface{}
+		expected    bool
+	}{
+		{"nil", nil, true},
+		{"zero struct containing a slice", list{}, true},
+		{"struct containing a slice", list{Values: []string{"a"}}, false},
+		{"zero int", 0, true},
+		{"int", 1, false},
+	}
+	for i, tc := range testCases {
+		tc := tc
+		i := i
+		t.Run(tc.description, func(t *testing.T) {
+			t.Parallel()
+			assert.Equal(t, tc.expected, gen.IsZero(tc.value), "invalid result for test %d", i)
+		})
+	}
+}
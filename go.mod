@@ -1,6 +1,6 @@
 module github.com/Nivl/go-params
 
-go 1.12
+go 1.18
 
 require (
 	github.com/Nivl/go-types v1.0.0
@@ -0,0 +1,91 @@
+package params
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"image"
+	"io"
+
+	// Registers the decoders used by image.DecodeConfig
+	_ "image/gif"
+	_ "image/jpeg"
+	_ "image/png"
+)
+
+// errInvalidWebP is returned when a WebP file has a header we cannot read
+var errInvalidWebP = errors.New("invalid webp header")
+
+// webpHeaderSize is the number of bytes needed to read the dimensions of
+// any kind of WebP file
+const webpHeaderSize = 30
+
+// imageDimensions returns the width and the height of an image by only
+// decoding its header. Supports PNG, JPEG, GIF, and WebP
+func imageDimensions(r io.ReadSeeker) (width, height int, err error) {
+	header := make([]byte, webpHeaderSize)
+	n, err := io.ReadFull(r, header)
+	if err != nil && err != io.ErrUnexpectedEOF {
+		return 0, 0, err
+	}
+	header = header[:n]
+
+	if isWebP(header) {
+		return webpDimensions(header)
+	}
+
+	if _, err = r.Seek(0, io.SeekStart); err != nil {
+		return 0, 0, err
+	}
+	cfg, _, err := image.DecodeConfig(r)
+	if err != nil {
+		return 0, 0, err
+	}
+	return cfg.Width, cfg.Height, nil
+}
+
+// isWebP checks if the given header is the one of a WebP file
+func isWebP(header []byte) bool {
+	return len(header) >= 16 &&
+		bytes.Equal(header[0:4], []byte("RIFF")) &&
+		bytes.Equal(header[8:12], []byte("WEBP"))
+}
+
+// webpDimensions returns the dimensions of a WebP image using its header.
+// https://developers.google.com/speed/webp/docs/riff_container
+func webpDimensions(header []byte) (width, height int, err error) {
+	if len(header) < webpHeaderSize {
+		return 0, 0, errInvalidWebP
+	}
+
+	// The data of the first chunk start at the 20th byte
+	data := header[20:]
+	switch string(header[12:16]) {
+	// Lossy format
+	case "VP8 ":
+		// 3 bytes of frame tag followed by a 3 bytes start code
+		if !bytes.Equal(data[3:6], []byte{0x9d, 0x01, 0x2a}) {
+			return 0, 0, errInvalidWebP
+		}
+		width = int(binary.LittleEndian.Uint16(data[6:8]) & 0x3fff)
+		height = int(binary.LittleEndian.Uint16(data[8:10]) & 0x3fff)
+	// Lossless format
+	case "VP8L":
+		if data[0] != 0x2f {
+			return 0, 0, errInvalidWebP
+		}
+		// 14 bits for the width, 14 bits for the height, both minus one
+		bits := binary.LittleEndian.Uint32(data[1:5])
+		width = int(bits&0x3fff) + 1
+		height = int((bits>>14)&0x3fff) + 1
+	// Extended format
+	case "VP8X":
+		// 1 byte of flags and 3 reserved bytes, followed by 24 bits for the
+		// width and 24 bits for the height, both minus one
+		width = int(uint32(data[4])|uint32(data[5])<<8|uint32(data[6])<<16) + 1
+		height = int(uint32(data[7])|uint32(data[8])<<8|uint32(data[9])<<16) + 1
+	default:
+		return 0, 0, errInvalidWebP
+	}
+	return width, height, nil
+}
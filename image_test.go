@@ -0,0 +1,194 @@
+package params_test
+
+import (
+	"bytes"
+	"encoding/binary"
+	"image"
+	"image/png"
+	"reflect"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	params "github.com/Nivl/go-params"
+	"github.com/Nivl/go-params/perror"
+)
+
+func TestValidateImageDimensions(t *testing.T) {
+	testCases := []struct {
+		description    string
+		tag            string
+		file           []byte
+		mime           string
+		expectedWidth  int
+		expectedHeight int
+		expectedError  error
+	}{
+		{
+			"PNG without constraints should work",
+			`json:"field_name"`,
+			newPNG(t, 300, 200),
+			"image/png",
+			300, 200,
+			nil,
+		},
+		{
+			"square PNG should work",
+			`json:"field_name" aspect:"1:1" min_width:"256" min_height:"256"`,
+			newPNG(t, 256, 256),
+			"image/png",
+			256, 256,
+			nil,
+		},
+		{
+			"16:9 PNG should work",
+			`json:"field_name" aspect:"16:9"`,
+			newPNG(t, 32, 18),
+			"image/png",
+			32, 18,
+			nil,
+		},
+		{
+			"non-square PNG should fail",
+			`json:"field_name" aspect:"1:1"`,
+			newPNG(t, 300, 200),
+			"image/png",
+			0, 0,
+			perror.New("field_name", params.ErrMsgWrongAspectRatio),
+		},
+		{
+			"narrow PNG should fail",
+			`json:"field_name" min_width:"256"`,
+			newPNG(t, 255, 300),
+			"image/png",
+			0, 0,
+			perror.New("field_name", params.ErrMsgImageTooNarrow),
+		},
+		{
+			"wide PNG should fail",
+			`json:"field_name" max_width:"100"`,
+			newPNG(t, 101, 10),
+			"image/png",
+			0, 0,
+			perror.New("field_name", params.ErrMsgImageTooWide),
+		},
+		{
+			"short PNG should fail",
+			`json:"field_name" min_height:"256"`,
+			newPNG(t, 300, 255),
+			"image/png",
+			0, 0,
+			perror.New("field_name", params.ErrMsgImageTooShort),
+		},
+		{
+			"tall PNG should fail",
+			`json:"field_name" max_height:"100"`,
+			newPNG(t, 10, 101),
+			"image/png",
+			0, 0,
+			perror.New("field_name", params.ErrMsgImageTooTall),
+		},
+		{
+			"lossy WebP should work",
+			`json:"field_name" min_width:"1"`,
+			newWebP("VP8 ", 640, 480),
+			"image/webp",
+			640, 480,
+			nil,
+		},
+		{
+			"lossless WebP should work",
+			`json:"field_name" min_width:"1"`,
+			newWebP("VP8L", 640, 480),
+			"image/webp",
+			640, 480,
+			nil,
+		},
+		{
+			"extended WebP should work",
+			`json:"field_name" min_width:"1"`,
+			newWebP("VP8X", 5000, 3000),
+			"image/webp",
+			5000, 3000,
+			nil,
+		},
+		{
+			"non-image without constraints should work",
+			`json:"field_name"`,
+			[]byte("not an image"),
+			"text/plain; charset=utf-8",
+			0, 0,
+			nil,
+		},
+		{
+			"non-image with constraints should fail",
+			`json:"field_name" max_width:"10"`,
+			[]byte("not an image"),
+			"text/plain; charset=utf-8",
+			0, 0,
+			perror.New("field_name", params.ErrMsgInvalidImage),
+		},
+		{
+			"corrupted image with constraints should fail",
+			`json:"field_name" max_width:"10"`,
+			[]byte("not an image"),
+			"image/png",
+			0, 0,
+			perror.New("field_name", params.ErrMsgInvalidImage),
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.description, func(t *testing.T) {
+			t.Parallel()
+			tag := reflect.StructTag(tc.tag)
+			opts, err := params.NewOptions(&tag)
+			require.NoError(t, err, "NewOptions() should not have failed)")
+
+			width, height, err := opts.ValidateImageDimensions(bytes.NewReader(tc.file), tc.mime)
+			if tc.expectedError != nil {
+				assert.Error(t, err, "ValidateImageDimensions() should have failed")
+				assert.Equal(t, tc.expectedError, err, "ValidateImageDimensions() returned an unexpected error")
+			} else {
+				require.NoError(t, err, "ValidateImageDimensions() should not have failed")
+				assert.Equal(t, tc.expectedWidth, width, "ValidateImageDimensions() returned an unexpected width")
+				assert.Equal(t, tc.expectedHeight, height, "ValidateImageDimensions() returned an unexpected height")
+			}
+		})
+	}
+}
+
+// newPNG returns the content of a PNG image of the given dimensions
+func newPNG(t *testing.T, width, height int) []byte {
+	buf := &bytes.Buffer{}
+	img := image.NewGray(image.Rect(0, 0, width, height))
+	if err := png.Encode(buf, img); err != nil {
+		t.Fatal(err)
+	}
+	return buf.Bytes()
+}
+
+// newWebP returns the header of a WebP image of the given format and
+// dimensions
+func newWebP(format string, width, height int) []byte {
+	data := make([]byte, 10)
+	switch format {
+	case "VP8 ":
+		copy(data[3:6], []byte{0x9d, 0x01, 0x2a})
+		binary.LittleEndian.PutUint16(data[6:8], uint16(width))
+		binary.LittleEndian.PutUint16(data[8:10], uint16(height))
+	case "VP8L":
+		data[0] = 0x2f
+		bits := uint32(width-1) | uint32(height-1)<<14
+		binary.LittleEndian.PutUint32(data[1:5], bits)
+	case "VP8X":
+		w, h := width-1, height-1
+		data[4], data[5], data[6] = byte(w), byte(w>>8), byte(w>>16)
+		data[7], data[8], data[9] = byte(h), byte(h>>8), byte(h>>16)
+	}
+
+	header := []byte("RIFF\x00\x00\x00\x00WEBP" + format + "\x00\x00\x00\x00")
+	return append(header, data...)
+}
@@ -0,0 +1,23 @@
+package params
+
+import (
+	"github.com/Nivl/go-params/formfile"
+)
+
+// FileInspector is an interface used to inspect the content of a file
+// before accepting it (malware scan, policy checks, ...)
+type FileInspector interface {
+	// InspectFile is called once the file has passed all the other checks.
+	// A perror.Error needs to be returned to reject the file, any other
+	// errors will be treated as system errors
+	InspectFile(field string, file *formfile.FormFile) error
+}
+
+// FileInspectorFunc is an adapter to allow the use of ordinary functions
+// as FileInspector
+type FileInspectorFunc func(field string, file *formfile.FormFile) error
+
+// InspectFile calls f(field, file)
+func (f FileInspectorFunc) InspectFile(field string, file *formfile.FormFile) error {
+	return f(field, file)
+}
@@ -0,0 +1,108 @@
+// Package paramsgentest contains params structs having methods generated
+// by paramsgen. It's used to make sure the generated code behaves like
+// the reflection
+package paramsgentest
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/Nivl/go-params/formfile"
+	"github.com/Nivl/go-types/date"
+)
+
+//go:generate go run ../../cmd/paramsgen
+
+// Sort represents the field used to sort a list
+type Sort string
+
+// Point represents a point of a map. It's a Scanner and a Formatter
+type Point struct {
+	X, Y int
+}
+
+// ScanString implements the params.Scanner interface
+func (p *Point) ScanString(value string) error {
+	if _, err := fmt.Sscanf(value, "%d:%d", &p.X, &p.Y); err != nil {
+		return errors.New("invalid point")
+	}
+	return nil
+}
+
+// FormatString implements the params.Formatter interface
+func (p *Point) FormatString() string {
+	return fmt.Sprintf("%d:%d", p.X, p.Y)
+}
+
+// Labels represents a comma separated list of labels. It's a Scanner and
+// a Formatter that cannot be compared using ==
+type Labels struct {
+	Values []string
+}
+
+// ScanString implements the params.Scanner interface
+func (l *Labels) ScanString(value string) error {
+	l.Values = strings.Split(value, ",")
+	return nil
+}
+
+// FormatString implements the params.Formatter interface
+func (l *Labels) FormatString() string {
+	return strings.Join(l.Values, ",")
+}
+
+// Pagination contains the params used to paginate a list
+type Pagination struct {
+	Page    int `from:"query" json:"page" min_int:"1" default:"1"`
+	PerPage int `from:"query" json:"per_page" alias:"perPage" max_int:"100" default:"20"`
+}
+
+// IsValid implements the params.CustomValidation interface
+func (p *Pagination) IsValid() (isValid bool, fieldFailing string, err error) {
+	if p.Page > 1000 {
+		return false, "page", errors.New("page too far")
+	}
+	return true, "", nil
+}
+
+// Filters contains the params used to filter a list
+type Filters struct {
+	Since   *date.Date  `from:"query" json:"since"`
+	Days    []date.Date `from:"query" json:"days"`
+	Archive *bool       `from:"query" json:"archive,omitempty"`
+}
+
+// ListParams contains the params of an endpoint listing data
+//
+//params:generate
+type ListParams struct {
+	Pagination
+	*Filters
+
+	Sort     Sort      `from:"query" json:"sort" enum:"name,created_at" default:"name"`
+	Search   *string   `from:"query,form" json:"q" params:"trim,noempty" conflict:"error"`
+	Tags     []string  `from:"query" json:"tags" params:"trim,noempty" maxitems:"3"`
+	IDs      []*int    `from:"query" json:"ids"`
+	Flags    []bool    `from:"query" json:"flags"`
+	Verbose  bool      `from:"header" json:"X-Verbose"`
+	Limit    *int      `from:"query" json:"limit" max_int:"50"`
+	Day      date.Date `from:"query" json:"day,omitempty"`
+	Before   time.Time `from:"query" json:"before,omitempty"`
+	Center   *Point    `from:"query" json:"center"`
+	Path     []Point   `from:"query" json:"path"`
+	Labels   Labels    `from:"query" json:"labels,omitempty"`
+	Internal string    `from:"query" json:"-"`
+}
+
+// UploadParams contains the params of an endpoint uploading files
+//
+//params:generate
+type UploadParams struct {
+	ID          string               `from:"url" json:"id" params:"uuid,required"`
+	Name        string               `from:"form" param:"name,omitempty" json:"title" maxlen:"20"`
+	Picture     *formfile.FormFile   `from:"file" json:"picture" checksum_field:"picture_sha256"`
+	Sum         string               `from:"form" json:"picture_sha256"`
+	Attachments []*formfile.FormFile `from:"file" json:"attachments" maxitems:"2"`
+}
@@ -0,0 +1,219 @@
+package paramsgentest_test
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"net/url"
+	"testing"
+
+	params "github.com/Nivl/go-params"
+	"github.com/Nivl/go-params/formfile"
+	"github.com/Nivl/go-params/internal/paramsgentest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// The types below have the same fields as the annotated structs, but
+// not their methods. They are used to run the reflection
+type (
+	listParams   paramsgentest.ListParams
+	uploadParams paramsgentest.UploadParams
+)
+
+var (
+	_ params.Parser    = &paramsgentest.ListParams{}
+	_ params.Extractor = &paramsgentest.ListParams{}
+	_ params.Parser    = &paramsgentest.UploadParams{}
+	_ params.Extractor = &paramsgentest.UploadParams{}
+)
+
+func TestListParams(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		description string
+		query       url.Values
+		form        url.Values
+		header      url.Values
+	}{
+		{"no params", url.Values{}, url.Values{}, url.Values{}},
+		{
+			"all the params",
+			url.Values{
+				"page":    []string{"2"},
+				"perPage": []string{"50"},
+				"since":   []string{"2020-01"},
+				"days":    []string{"2020-01-01", "2020-02-01"},
+				"archive": []string{"true"},
+				"sort":    []string{"created_at"},
+				"q":       []string{" search "},
+				"tags":    []string{" a", "b "},
+				"ids":     []string{"1", "2"},
+				"flags":   []string{"true", "false"},
+				"limit":   []string{"10"},
+				"day":     []string{"2020-03-04"},
+				"before":  []string{"2020-03-04T10:11:12.5Z"},
+				"center":  []string{"1:2"},
+				"path":    []string{"1:2", "-3:4"},
+				"labels":  []string{"a,b"},
+			},
+			url.Values{"q": []string{" search "}},
+			url.Values{"X-Verbose": []string{"1"}},
+		},
+		{"invalid int", url.Values{"page": []string{"nope"}}, url.Values{}, url.Values{}},
+		{"int too small", url.Values{"page": []string{"0"}}, url.Values{}, url.Values{}},
+		{"invalid bool", url.Values{"flags": []string{"true", "nope"}}, url.Values{}, url.Values{}},
+		{"invalid date", url.Values{"since": []string{"nope"}}, url.Values{}, url.Values{}},
+		{"time with an offset", url.Values{"before": []string{"2020-03-04T12:11:12+02:00"}}, url.Values{}, url.Values{}},
+		{"invalid time", url.Values{"before": []string{"2020-03-04"}}, url.Values{}, url.Values{}},
+		{"invalid scanner", url.Values{"path": []string{"1:2", "nope"}}, url.Values{}, url.Values{}},
+		{"invalid enum", url.Values{"sort": []string{"nope"}}, url.Values{}, url.Values{}},
+		{"empty array item", url.Values{"tags": []string{"a", " "}}, url.Values{}, url.Values{}},
+		{"too many items", url.Values{"tags": []string{"a", "b", "c", "d"}}, url.Values{}, url.Values{}},
+		{"conflicting sources", url.Values{"q": []string{"a"}}, url.Values{"q": []string{"b"}}, url.Values{}},
+		{"embedded custom validation", url.Values{"page": []string{"1001"}}, url.Values{}, url.Values{}},
+		{"empty arrays", url.Values{"tags": []string{}, "days": []string{}}, url.Values{}, url.Values{}},
+	}
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.description, func(t *testing.T) {
+			t.Parallel()
+
+			sources := map[string]url.Values{
+				"query":  tc.query,
+				"form":   tc.form,
+				"header": tc.header,
+			}
+
+			generated := &paramsgentest.ListParams{}
+			generatedErr := params.New(generated).Parse(sources, nil)
+			reflected := &listParams{}
+			reflectedErr := params.New(reflected).Parse(sources, nil)
+
+			assert.Equal(t, reflectedErr, generatedErr, "the errors should be the same")
+			assert.Equal(t, reflected, (*listParams)(generated), "the structs should be the same")
+
+			generatedSources, generatedFiles := params.New(generated).Extract()
+			reflectedSources, reflectedFiles := params.New(reflected).Extract()
+			assert.Equal(t, reflectedSources, generatedSources, "the extracted sources should be the same")
+			assert.Equal(t, reflectedFiles, generatedFiles, "the extracted files should be the same")
+
+			// The extracted data should give back the same struct
+			if generatedErr == nil {
+				for source := range sources {
+					if _, found := generatedSources[source]; !found {
+						generatedSources[source] = url.Values{}
+					}
+				}
+				parsed := &paramsgentest.ListParams{}
+				err := params.New(parsed).Parse(generatedSources, nil)
+				require.NoError(t, err, "Parse() should have succeed on the extracted data")
+				assert.Equal(t, generated, parsed, "Parse(Extract(x)) should be equal to x")
+			}
+		})
+	}
+}
+
+func TestUploadParams(t *testing.T) {
+	t.Parallel()
+
+	content := []byte("content")
+	digest := sha256.Sum256(content)
+	checksum := hex.EncodeToString(digest[:])
+
+	testCases := []struct {
+		description string
+		id          string
+		form        url.Values
+		files       map[string][][]byte
+	}{
+		{"no params", "", url.Values{}, map[string][][]byte{}},
+		{
+			"all the params",
+			"a0a0a0a0-a0a0-4a0a-8a0a-a0a0a0a0a0a0",
+			url.Values{"name": []string{"picture"}, "picture_sha256": []string{checksum}},
+			map[string][][]byte{
+				"picture":     {content},
+				"attachments": {content, content},
+			},
+		},
+		{"invalid uuid", "nope", url.Values{}, map[string][][]byte{}},
+		{"string too long", "a0a0a0a0-a0a0-4a0a-8a0a-a0a0a0a0a0a0", url.Values{"name": []string{"abcdefghijklmnopqrstuvwxyz"}}, map[string][][]byte{}},
+		{
+			"invalid checksum",
+			"a0a0a0a0-a0a0-4a0a-8a0a-a0a0a0a0a0a0",
+			url.Values{"picture_sha256": []string{"nope"}},
+			map[string][][]byte{"picture": {content}},
+		},
+		{
+			"too many files",
+			"a0a0a0a0-a0a0-4a0a-8a0a-a0a0a0a0a0a0",
+			url.Values{},
+			map[string][][]byte{"attachments": {content, content, content}},
+		},
+	}
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.description, func(t *testing.T) {
+			t.Parallel()
+
+			sources := map[string]url.Values{
+				"url":  {"id": []string{tc.id}},
+				"form": tc.form,
+			}
+			newHolder := func() formfile.FileHolder {
+				holder := formfile.NewMemoryHolder(nil)
+				for key, files := range tc.files {
+					for _, f := range files {
+						holder.Add(key, key+".txt", "text/plain", f)
+					}
+				}
+				return holder
+			}
+
+			generated := &paramsgentest.UploadParams{}
+			generatedErr := params.New(generated).Parse(sources, newHolder())
+			reflected := &uploadParams{}
+			reflectedErr := params.New(reflected).Parse(sources, newHolder())
+			assert.Equal(t, reflectedErr, generatedErr, "the errors should be the same")
+			if generatedErr != nil {
+				return
+			}
+
+			// The files are opened twice, so we only compare their data
+			assert.Equal(t, reflected.ID, generated.ID, "the IDs should be the same")
+			assert.Equal(t, reflected.Name, generated.Name, "the names should be the same")
+			assert.Equal(t, reflected.Sum, generated.Sum, "the checksums should be the same")
+			assert.Equal(t, reflected.Picture == nil, generated.Picture == nil, "the pictures should be the same")
+			if generated.Picture != nil {
+				assert.Equal(t, reflected.Picture.SHA256, generated.Picture.SHA256, "the pictures should be the same")
+			}
+			require.Len(t, generated.Attachments, len(reflected.Attachments), "the attachments should be the same")
+			for i, a := range generated.Attachments {
+				assert.Equal(t, reflected.Attachments[i].Header.Filename, a.Header.Filename, "the attachments should be the same")
+			}
+
+			generatedSources, generatedFiles := params.New(generated).Extract()
+			reflectedSources, _ := params.New(reflected).Extract()
+			assert.Equal(t, reflectedSources, generatedSources, "the extracted sources should be the same")
+			assert.Equal(t, generated.Picture, generatedFiles["picture"], "the picture should be extracted")
+		})
+	}
+}
+
+func TestUnsupportedConfig(t *testing.T) {
+	t.Parallel()
+
+	// The generated code doesn't support the strict mode, so the
+	// reflection needs to be used
+	sources := map[string]url.Values{
+		"query":  {"unknown": []string{"value"}},
+		"form":   {},
+		"header": {},
+	}
+	p := params.New(&paramsgentest.ListParams{})
+	p.SetStrict(true)
+	err := p.Parse(sources, nil)
+	require.Error(t, err, "Parse() should have failed")
+	assert.Contains(t, err.Error(), params.ErrMsgUnknownParameter, "the unknown param should have been detected")
+}
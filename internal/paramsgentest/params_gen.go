@@ -0,0 +1,738 @@
+// Code generated by paramsgen. DO NOT EDIT.
+
+package paramsgentest
+
+import (
+	"net/url"
+
+	params "github.com/Nivl/go-params"
+	"github.com/Nivl/go-params/formfile"
+	"github.com/Nivl/go-params/gen"
+	"github.com/Nivl/go-params/perror"
+	"github.com/Nivl/go-types/date"
+)
+
+// The options of the fields are parsed once, when the package is
+// initialized. The invalid tags are reported by the methods
+var (
+	optsFiltersArchive          = gen.NewOptions(`from:"query" json:"archive,omitempty"`, "Archive")
+	optsFiltersDays             = gen.NewOptions(`from:"query" json:"days"`, "Days")
+	optsFiltersSince            = gen.NewOptions(`from:"query" json:"since"`, "Since")
+	optsListParamsBefore        = gen.NewOptions(`from:"query" json:"before,omitempty"`, "Before")
+	optsListParamsCenter        = gen.NewOptions(`from:"query" json:"center"`, "Center")
+	optsListParamsDay           = gen.NewOptions(`from:"query" json:"day,omitempty"`, "Day")
+	optsListParamsFlags         = gen.NewOptions(`from:"query" json:"flags"`, "Flags")
+	optsListParamsIDs           = gen.NewOptions(`from:"query" json:"ids"`, "IDs")
+	optsListParamsLabels        = gen.NewOptions(`from:"query" json:"labels,omitempty"`, "Labels")
+	optsListParamsLimit         = gen.NewOptions(`from:"query" json:"limit" max_int:"50"`, "Limit")
+	optsListParamsPath          = gen.NewOptions(`from:"query" json:"path"`, "Path")
+	optsListParamsSearch        = gen.NewOptions(`from:"query,form" json:"q" params:"trim,noempty" conflict:"error"`, "Search")
+	optsListParamsSort          = gen.NewOptions(`from:"query" json:"sort" enum:"name,created_at" default:"name"`, "Sort")
+	optsListParamsTags          = gen.NewOptions(`from:"query" json:"tags" params:"trim,noempty" maxitems:"3"`, "Tags")
+	optsListParamsVerbose       = gen.NewOptions(`from:"header" json:"X-Verbose"`, "Verbose")
+	optsPaginationPage          = gen.NewOptions(`from:"query" json:"page" min_int:"1" default:"1"`, "Page")
+	optsPaginationPerPage       = gen.NewOptions(`from:"query" json:"per_page" alias:"perPage" max_int:"100" default:"20"`, "PerPage")
+	optsUploadParamsAttachments = gen.NewOptions(`from:"file" json:"attachments" maxitems:"2"`, "Attachments")
+	optsUploadParamsID          = gen.NewOptions(`from:"url" json:"id" params:"uuid,required"`, "ID")
+	optsUploadParamsName        = gen.NewOptions(`from:"form" param:"name,omitempty" json:"title" maxlen:"20"`, "Name")
+	optsUploadParamsPicture     = gen.NewOptions(`from:"file" json:"picture" checksum_field:"picture_sha256"`, "Picture")
+	optsUploadParamsSum         = gen.NewOptions(`from:"form" json:"picture_sha256"`, "Sum")
+)
+
+// ParseParams fills the struct using the provided sources.
+// It's used by params.Parse instead of reflection
+func (p *ListParams) ParseParams(sources map[string]url.Values, fileHolder formfile.FileHolder) error {
+	if err := gen.Check(
+		optsPaginationPage,
+		optsPaginationPerPage,
+		optsFiltersSince,
+		optsFiltersDays,
+		optsFiltersArchive,
+		optsListParamsSort,
+		optsListParamsSearch,
+		optsListParamsTags,
+		optsListParamsIDs,
+		optsListParamsFlags,
+		optsListParamsVerbose,
+		optsListParamsLimit,
+		optsListParamsDay,
+		optsListParamsBefore,
+		optsListParamsCenter,
+		optsListParamsPath,
+		optsListParamsLabels,
+	); err != nil {
+		return err
+	}
+
+	// embedded Pagination
+
+	// p.Pagination.Page
+	{
+		source, err := gen.SelectSource(sources, optsPaginationPage, "query")
+		if err != nil {
+			return err
+		}
+		value, provided, err := gen.FieldValue(source, optsPaginationPage, "1")
+		if err != nil {
+			return err
+		}
+		if provided || value != "" {
+			n, err := gen.IntValue(optsPaginationPage, value)
+			if err != nil {
+				return err
+			}
+			p.Pagination.Page = n
+		}
+	}
+
+	// p.Pagination.PerPage
+	{
+		source, err := gen.SelectSource(sources, optsPaginationPerPage, "query")
+		if err != nil {
+			return err
+		}
+		value, provided, err := gen.FieldValue(source, optsPaginationPerPage, "20")
+		if err != nil {
+			return err
+		}
+		if provided || value != "" {
+			n, err := gen.IntValue(optsPaginationPerPage, value)
+			if err != nil {
+				return err
+			}
+			p.Pagination.PerPage = n
+		}
+	}
+
+	if validator, ok := interface{}(&p.Pagination).(params.CustomValidation); ok {
+		isValid, field, err := validator.IsValid()
+		if !isValid {
+			return perror.New(field, err.Error())
+		}
+	}
+
+	// embedded *Filters
+	if p.Filters == nil {
+		p.Filters = new(Filters)
+	}
+
+	// p.Filters.Since
+	{
+		source, err := gen.SelectSource(sources, optsFiltersSince, "query")
+		if err != nil {
+			return err
+		}
+		value, provided, err := gen.FieldValue(source, optsFiltersSince, "")
+		if err != nil {
+			return err
+		}
+		if provided || value != "" {
+			v := new(date.Date)
+			p.Filters.Since = v
+			if err := gen.ScanValue(optsFiltersSince, v, value); err != nil {
+				return err
+			}
+		}
+	}
+
+	// p.Filters.Days
+	{
+		source, err := gen.SelectSource(sources, optsFiltersDays, "query")
+		if err != nil {
+			return err
+		}
+		values, provided, err := gen.FieldValues(source, optsFiltersDays, "")
+		if err != nil {
+			return err
+		}
+		if provided || len(values) > 0 {
+			list := make([]date.Date, len(values))
+			for i, value := range values {
+				if err := gen.ScanValue(optsFiltersDays, &list[i], value); err != nil {
+					return err
+				}
+			}
+			p.Filters.Days = list
+		}
+	}
+
+	// p.Filters.Archive
+	{
+		source, err := gen.SelectSource(sources, optsFiltersArchive, "query")
+		if err != nil {
+			return err
+		}
+		value, provided, err := gen.FieldValue(source, optsFiltersArchive, "")
+		if err != nil {
+			return err
+		}
+		if provided || value != "" {
+			b, err := gen.BoolValue(optsFiltersArchive, value)
+			if err != nil {
+				return err
+			}
+			v := b
+			p.Filters.Archive = &v
+		}
+	}
+
+	if validator, ok := interface{}(p.Filters).(params.CustomValidation); ok {
+		isValid, field, err := validator.IsValid()
+		if !isValid {
+			return perror.New(field, err.Error())
+		}
+	}
+
+	// p.Sort
+	{
+		source, err := gen.SelectSource(sources, optsListParamsSort, "query")
+		if err != nil {
+			return err
+		}
+		value, provided, err := gen.FieldValue(source, optsListParamsSort, "name")
+		if err != nil {
+			return err
+		}
+		if provided || value != "" {
+			p.Sort = Sort(value)
+		}
+	}
+
+	// p.Search
+	{
+		source, err := gen.SelectSource(sources, optsListParamsSearch, "query", "form")
+		if err != nil {
+			return err
+		}
+		value, provided, err := gen.FieldValue(source, optsListParamsSearch, "")
+		if err != nil {
+			return err
+		}
+		if provided || value != "" {
+			v := value
+			p.Search = &v
+		}
+	}
+
+	// p.Tags
+	{
+		source, err := gen.SelectSource(sources, optsListParamsTags, "query")
+		if err != nil {
+			return err
+		}
+		values, provided, err := gen.FieldValues(source, optsListParamsTags, "")
+		if err != nil {
+			return err
+		}
+		if provided || len(values) > 0 {
+			list := make([]string, len(values))
+			for i, value := range values {
+				list[i] = value
+			}
+			p.Tags = list
+		}
+	}
+
+	// p.IDs
+	{
+		source, err := gen.SelectSource(sources, optsListParamsIDs, "query")
+		if err != nil {
+			return err
+		}
+		values, provided, err := gen.FieldValues(source, optsListParamsIDs, "")
+		if err != nil {
+			return err
+		}
+		if provided || len(values) > 0 {
+			list := make([]*int, len(values))
+			for i, value := range values {
+				n, err := gen.IntValue(optsListParamsIDs, value)
+				if err != nil {
+					return err
+				}
+				v := n
+				list[i] = &v
+			}
+			p.IDs = list
+		}
+	}
+
+	// p.Flags
+	{
+		source, err := gen.SelectSource(sources, optsListParamsFlags, "query")
+		if err != nil {
+			return err
+		}
+		values, provided, err := gen.FieldValues(source, optsListParamsFlags, "")
+		if err != nil {
+			return err
+		}
+		if provided || len(values) > 0 {
+			list := make([]bool, len(values))
+			for i, value := range values {
+				b, err := gen.BoolValue(optsListParamsFlags, value)
+				if err != nil {
+					return err
+				}
+				list[i] = b
+			}
+			p.Flags = list
+		}
+	}
+
+	// p.Verbose
+	{
+		source, err := gen.SelectSource(sources, optsListParamsVerbose, "header")
+		if err != nil {
+			return err
+		}
+		value, provided, err := gen.FieldValue(source, optsListParamsVerbose, "")
+		if err != nil {
+			return err
+		}
+		if provided || value != "" {
+			b, err := gen.BoolValue(optsListParamsVerbose, value)
+			if err != nil {
+				return err
+			}
+			p.Verbose = b
+		}
+	}
+
+	// p.Limit
+	{
+		source, err := gen.SelectSource(sources, optsListParamsLimit, "query")
+		if err != nil {
+			return err
+		}
+		value, provided, err := gen.FieldValue(source, optsListParamsLimit, "")
+		if err != nil {
+			return err
+		}
+		if provided || value != "" {
+			n, err := gen.IntValue(optsListParamsLimit, value)
+			if err != nil {
+				return err
+			}
+			v := n
+			p.Limit = &v
+		}
+	}
+
+	// p.Day
+	{
+		source, err := gen.SelectSource(sources, optsListParamsDay, "query")
+		if err != nil {
+			return err
+		}
+		value, provided, err := gen.FieldValue(source, optsListParamsDay, "")
+		if err != nil {
+			return err
+		}
+		if provided || value != "" {
+			if err := gen.ScanValue(optsListParamsDay, &p.Day, value); err != nil {
+				return err
+			}
+		}
+	}
+
+	// p.Before
+	{
+		source, err := gen.SelectSource(sources, optsListParamsBefore, "query")
+		if err != nil {
+			return err
+		}
+		value, provided, err := gen.FieldValue(source, optsListParamsBefore, "")
+		if err != nil {
+			return err
+		}
+		if provided || value != "" {
+			t, err := gen.TimeValue(optsListParamsBefore, value)
+			if err != nil {
+				return err
+			}
+			p.Before = t
+		}
+	}
+
+	// p.Center
+	{
+		source, err := gen.SelectSource(sources, optsListParamsCenter, "query")
+		if err != nil {
+			return err
+		}
+		value, provided, err := gen.FieldValue(source, optsListParamsCenter, "")
+		if err != nil {
+			return err
+		}
+		if provided || value != "" {
+			v := new(Point)
+			p.Center = v
+			if err := gen.ScanValue(optsListParamsCenter, v, value); err != nil {
+				return err
+			}
+		}
+	}
+
+	// p.Path
+	{
+		source, err := gen.SelectSource(sources, optsListParamsPath, "query")
+		if err != nil {
+			return err
+		}
+		values, provided, err := gen.FieldValues(source, optsListParamsPath, "")
+		if err != nil {
+			return err
+		}
+		if provided || len(values) > 0 {
+			list := make([]Point, len(values))
+			for i, value := range values {
+				if err := gen.ScanValue(optsListParamsPath, &list[i], value); err != nil {
+					return err
+				}
+			}
+			p.Path = list
+		}
+	}
+
+	// p.Labels
+	{
+		source, err := gen.SelectSource(sources, optsListParamsLabels, "query")
+		if err != nil {
+			return err
+		}
+		value, provided, err := gen.FieldValue(source, optsListParamsLabels, "")
+		if err != nil {
+			return err
+		}
+		if provided || value != "" {
+			if err := gen.ScanValue(optsListParamsLabels, &p.Labels, value); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// ExtractParams returns the sources and files that would be
+// used to fill the struct. It's used by params.Extract instead of
+// reflection
+func (p *ListParams) ExtractParams() (sources map[string]url.Values, files map[string]*formfile.FormFile, multipleFiles map[string][]*formfile.FormFile, err error) {
+	if err := gen.Check(
+		optsPaginationPage,
+		optsPaginationPerPage,
+		optsFiltersSince,
+		optsFiltersDays,
+		optsFiltersArchive,
+		optsListParamsSort,
+		optsListParamsSearch,
+		optsListParamsTags,
+		optsListParamsIDs,
+		optsListParamsFlags,
+		optsListParamsVerbose,
+		optsListParamsLimit,
+		optsListParamsDay,
+		optsListParamsBefore,
+		optsListParamsCenter,
+		optsListParamsPath,
+		optsListParamsLabels,
+	); err != nil {
+		return nil, nil, nil, err
+	}
+
+	sources = map[string]url.Values{}
+	files = map[string]*formfile.FormFile{}
+	multipleFiles = map[string][]*formfile.FormFile{}
+	// p.Pagination.Page
+	if _, found := sources["query"]; !found {
+		sources["query"] = url.Values{}
+	}
+	sources["query"].Set("page", gen.FormatParam(optsPaginationPage, p.Pagination.Page))
+
+	// p.Pagination.PerPage
+	if _, found := sources["query"]; !found {
+		sources["query"] = url.Values{}
+	}
+	sources["query"].Set("per_page", gen.FormatParam(optsPaginationPerPage, p.Pagination.PerPage))
+	if p.Filters != nil {
+		// p.Filters.Since
+		if p.Filters.Since != nil {
+			if _, found := sources["query"]; !found {
+				sources["query"] = url.Values{}
+			}
+			sources["query"].Set("since", gen.FormatParam(optsFiltersSince, p.Filters.Since))
+		}
+
+		// p.Filters.Days
+		if _, found := sources["query"]; !found {
+			sources["query"] = url.Values{}
+		}
+		if p.Filters.Days != nil {
+			if len(p.Filters.Days) == 0 {
+				sources["query"]["days"] = []string{}
+			}
+			for _, v := range p.Filters.Days {
+				sources["query"].Add("days", gen.FormatParam(optsFiltersDays, v))
+			}
+		}
+
+		// p.Filters.Archive
+		if p.Filters.Archive != nil {
+			if _, found := sources["query"]; !found {
+				sources["query"] = url.Values{}
+			}
+			if *p.Filters.Archive {
+				sources["query"].Set("archive", gen.FormatParam(optsFiltersArchive, p.Filters.Archive))
+			}
+		}
+	}
+
+	// p.Sort
+	if _, found := sources["query"]; !found {
+		sources["query"] = url.Values{}
+	}
+	sources["query"].Set("sort", gen.FormatParam(optsListParamsSort, p.Sort))
+
+	// p.Search
+	if p.Search != nil {
+		if _, found := sources["query"]; !found {
+			sources["query"] = url.Values{}
+		}
+		sources["query"].Set("q", gen.FormatParam(optsListParamsSearch, p.Search))
+	}
+
+	// p.Tags
+	if _, found := sources["query"]; !found {
+		sources["query"] = url.Values{}
+	}
+	if p.Tags != nil {
+		if len(p.Tags) == 0 {
+			sources["query"]["tags"] = []string{}
+		}
+		for _, v := range p.Tags {
+			sources["query"].Add("tags", gen.FormatParam(optsListParamsTags, v))
+		}
+	}
+
+	// p.IDs
+	if _, found := sources["query"]; !found {
+		sources["query"] = url.Values{}
+	}
+	if p.IDs != nil {
+		if len(p.IDs) == 0 {
+			sources["query"]["ids"] = []string{}
+		}
+		for _, v := range p.IDs {
+			sources["query"].Add("ids", gen.FormatParam(optsListParamsIDs, v))
+		}
+	}
+
+	// p.Flags
+	if _, found := sources["query"]; !found {
+		sources["query"] = url.Values{}
+	}
+	if p.Flags != nil {
+		if len(p.Flags) == 0 {
+			sources["query"]["flags"] = []string{}
+		}
+		for _, v := range p.Flags {
+			sources["query"].Add("flags", gen.FormatParam(optsListParamsFlags, v))
+		}
+	}
+
+	// p.Verbose
+	if _, found := sources["header"]; !found {
+		sources["header"] = url.Values{}
+	}
+	sources["header"].Set("X-Verbose", gen.FormatParam(optsListParamsVerbose, p.Verbose))
+
+	// p.Limit
+	if p.Limit != nil {
+		if _, found := sources["query"]; !found {
+			sources["query"] = url.Values{}
+		}
+		sources["query"].Set("limit", gen.FormatParam(optsListParamsLimit, p.Limit))
+	}
+
+	// p.Day
+	if _, found := sources["query"]; !found {
+		sources["query"] = url.Values{}
+	}
+	if !gen.IsZero(p.Day) {
+		sources["query"].Set("day", gen.FormatParam(optsListParamsDay, p.Day))
+	}
+
+	// p.Before
+	if _, found := sources["query"]; !found {
+		sources["query"] = url.Values{}
+	}
+	if !p.Before.IsZero() {
+		sources["query"].Set("before", gen.FormatParam(optsListParamsBefore, p.Before))
+	}
+
+	// p.Center
+	if p.Center != nil {
+		if _, found := sources["query"]; !found {
+			sources["query"] = url.Values{}
+		}
+		sources["query"].Set("center", gen.FormatParam(optsListParamsCenter, p.Center))
+	}
+
+	// p.Path
+	if _, found := sources["query"]; !found {
+		sources["query"] = url.Values{}
+	}
+	if p.Path != nil {
+		if len(p.Path) == 0 {
+			sources["query"]["path"] = []string{}
+		}
+		for _, v := range p.Path {
+			sources["query"].Add("path", gen.FormatParam(optsListParamsPath, v))
+		}
+	}
+
+	// p.Labels
+	if _, found := sources["query"]; !found {
+		sources["query"] = url.Values{}
+	}
+	if !gen.IsZero(p.Labels) {
+		sources["query"].Set("labels", gen.FormatParam(optsListParamsLabels, p.Labels))
+	}
+	return sources, files, multipleFiles, nil
+}
+
+// ParseParams fills the struct using the provided sources.
+// It's used by params.Parse instead of reflection
+func (p *UploadParams) ParseParams(sources map[string]url.Values, fileHolder formfile.FileHolder) error {
+	if err := gen.Check(
+		optsUploadParamsID,
+		optsUploadParamsName,
+		optsUploadParamsPicture,
+		optsUploadParamsSum,
+		optsUploadParamsAttachments,
+	); err != nil {
+		return err
+	}
+
+	// p.ID
+	{
+		source, err := gen.SelectSource(sources, optsUploadParamsID, "url")
+		if err != nil {
+			return err
+		}
+		value, provided, err := gen.FieldValue(source, optsUploadParamsID, "")
+		if err != nil {
+			return err
+		}
+		if provided || value != "" {
+			p.ID = value
+		}
+	}
+
+	// p.Name
+	{
+		source, err := gen.SelectSource(sources, optsUploadParamsName, "form")
+		if err != nil {
+			return err
+		}
+		value, provided, err := gen.FieldValue(source, optsUploadParamsName, "")
+		if err != nil {
+			return err
+		}
+		if provided || value != "" {
+			p.Name = value
+		}
+	}
+
+	// p.Picture
+	if file, err := gen.FormFile(fileHolder, optsUploadParamsPicture); err != nil {
+		return err
+	} else if file != nil {
+		p.Picture = file
+	}
+
+	// p.Sum
+	{
+		source, err := gen.SelectSource(sources, optsUploadParamsSum, "form")
+		if err != nil {
+			return err
+		}
+		value, provided, err := gen.FieldValue(source, optsUploadParamsSum, "")
+		if err != nil {
+			return err
+		}
+		if provided || value != "" {
+			p.Sum = value
+		}
+	}
+
+	// p.Attachments
+	if files, err := gen.FormFiles(fileHolder, optsUploadParamsAttachments); err != nil {
+		return err
+	} else if len(files) > 0 {
+		p.Attachments = files
+	}
+
+	if p.Picture != nil && p.Sum != "" {
+		if err := optsUploadParamsPicture.ValidateChecksum(p.Sum, p.Picture.SHA256, p.Picture.MD5); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// ExtractParams returns the sources and files that would be
+// used to fill the struct. It's used by params.Extract instead of
+// reflection
+func (p *UploadParams) ExtractParams() (sources map[string]url.Values, files map[string]*formfile.FormFile, multipleFiles map[string][]*formfile.FormFile, err error) {
+	if err := gen.Check(
+		optsUploadParamsID,
+		optsUploadParamsName,
+		optsUploadParamsPicture,
+		optsUploadParamsSum,
+		optsUploadParamsAttachments,
+	); err != nil {
+		return nil, nil, nil, err
+	}
+
+	sources = map[string]url.Values{}
+	files = map[string]*formfile.FormFile{}
+	multipleFiles = map[string][]*formfile.FormFile{}
+
+	// p.ID
+	if _, found := sources["url"]; !found {
+		sources["url"] = url.Values{}
+	}
+	sources["url"].Set("id", gen.FormatParam(optsUploadParamsID, p.ID))
+
+	// p.Name
+	if _, found := sources["form"]; !found {
+		sources["form"] = url.Values{}
+	}
+	if p.Name != "" {
+		sources["form"].Set("name", gen.FormatParam(optsUploadParamsName, p.Name))
+	}
+
+	// p.Picture
+	if p.Picture != nil {
+		if _, found := sources["file"]; !found {
+			sources["file"] = url.Values{}
+		}
+		files["picture"] = p.Picture
+	}
+
+	// p.Sum
+	if _, found := sources["form"]; !found {
+		sources["form"] = url.Values{}
+	}
+	sources["form"].Set("picture_sha256", gen.FormatParam(optsUploadParamsSum, p.Sum))
+
+	// p.Attachments
+	if _, found := sources["file"]; !found {
+		sources["file"] = url.Values{}
+	}
+	if p.Attachments != nil {
+		multipleFiles["attachments"] = p.Attachments
+	}
+	return sources, files, multipleFiles, nil
+}
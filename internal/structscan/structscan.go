@@ -0,0 +1,424 @@
+// Package structscan finds the params structs of a Go package without
+// having to compile it
+package structscan
+
+import (
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	goTypes "go/types"
+	"os"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+
+	params "github.com/Nivl/go-params"
+)
+
+// Package represents a parsed Go package
+type Package struct {
+	// Name contains the name of the package
+	Name string
+
+	// Dir contains the directory of the package
+	Dir string
+
+	// Structs contains all the structs of the package, in the order
+	// they are declared
+	Structs []*Struct
+
+	// Types contains the underlying type of all the types of the package
+	// that are not structs, like "string" for type Sort string
+	Types map[string]string
+}
+
+// Struct represents a struct type declared in a package
+type Struct struct {
+	// Name contains the name of the type
+	Name string
+
+	// Doc contains the doc comment of the type
+	Doc string
+
+	// Directives contains the directives set in the doc comment of the
+	// type, like "params:generate" for //params:generate
+	Directives []string
+
+	// Imports contains the imports of the file declaring the struct,
+	// by name
+	Imports map[string]string
+
+	// Fields contains the fields of the struct
+	Fields []*Field
+}
+
+// Field represents a field of a struct
+type Field struct {
+	// Name contains the name of the field. The name of an embedded
+	// field is the name of its type
+	Name string
+
+	// Type contains the type of the field, as written in the code
+	Type string
+
+	// Tag contains the tag of the field
+	Tag reflect.StructTag
+
+	// Embedded means the field is an embedded struct
+	Embedded bool
+
+	// Exported means the field is exported
+	Exported bool
+}
+
+// Load parses the non-test Go files of a directory
+func Load(dir string) (*Package, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, err
+	}
+	filenames := []string{}
+	for _, entry := range entries {
+		name := entry.Name()
+		if entry.IsDir() || !strings.HasSuffix(name, ".go") || strings.HasSuffix(name, "_test.go") {
+			continue
+		}
+		filenames = append(filenames, name)
+	}
+	sort.Strings(filenames)
+
+	pkg := &Package{
+		Dir:   dir,
+		Types: map[string]string{},
+	}
+	fset := token.NewFileSet()
+	for _, filename := range filenames {
+		file, err := parser.ParseFile(fset, filepath.Join(dir, filename), nil, parser.ParseComments)
+		if err != nil {
+			return nil, err
+		}
+		if pkg.Name == "" {
+			pkg.Name = file.Name.Name
+		}
+		if file.Name.Name != pkg.Name {
+			return nil, fmt.Errorf("found packages %s and %s in %s", pkg.Name, file.Name.Name, dir)
+		}
+
+		structs, err := fileStructs(file, pkg.Types)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %s", filename, err.Error())
+		}
+		pkg.Structs = append(pkg.Structs, structs...)
+	}
+
+	if pkg.Name == "" {
+		return nil, fmt.Errorf("no Go files in %s", dir)
+	}
+	return pkg, nil
+}
+
+// fileStructs returns the structs declared at the top level of a file.
+// The underlying type of the other types are added to types
+func fileStructs(file *ast.File, types map[string]string) ([]*Struct, error) {
+	imports, err := fileImports(file)
+	if err != nil {
+		return nil, err
+	}
+
+	structs := []*Struct{}
+	for _, decl := range file.Decls {
+		genDecl, ok := decl.(*ast.GenDecl)
+		if !ok || genDecl.Tok != token.TYPE {
+			continue
+		}
+		for _, spec := range genDecl.Specs {
+			typeSpec := spec.(*ast.TypeSpec)
+			structType, ok := typeSpec.Type.(*ast.StructType)
+			if !ok {
+				types[typeSpec.Name.Name] = goTypes.ExprString(typeSpec.Type)
+				continue
+			}
+
+			// the doc is attached to the declaration when the type is
+			// not declared in a group
+			doc := typeSpec.Doc
+			if doc == nil && len(genDecl.Specs) == 1 {
+				doc = genDecl.Doc
+			}
+
+			s := &Struct{
+				Name:       typeSpec.Name.Name,
+				Doc:        strings.TrimSpace(doc.Text()),
+				Directives: directives(doc),
+				Imports:    imports,
+			}
+			for _, field := range structType.Fields.List {
+				fields, err := newFields(field)
+				if err != nil {
+					return nil, fmt.Errorf("struct %s: %s", s.Name, err.Error())
+				}
+				s.Fields = append(s.Fields, fields...)
+			}
+			structs = append(structs, s)
+		}
+	}
+	return structs, nil
+}
+
+// directives returns the directives of a comment, without the leading
+// slashes
+func directives(doc *ast.CommentGroup) []string {
+	list := []string{}
+	if doc == nil {
+		return list
+	}
+	for _, comment := range doc.List {
+		text := strings.TrimPrefix(comment.Text, "//")
+		// a directive has no spaces after the slashes, and contains a colon
+		if text == comment.Text || strings.HasPrefix(text, " ") || !strings.Contains(text, ":") {
+			continue
+		}
+		list = append(list, strings.TrimSpace(text))
+	}
+	return list
+}
+
+// fileImports returns the packages imported by a file, by name
+func fileImports(file *ast.File) (map[string]string, error) {
+	imports := map[string]string{}
+	for _, spec := range file.Imports {
+		path, err := strconv.Unquote(spec.Path.Value)
+		if err != nil {
+			return nil, err
+		}
+
+		name := ""
+		if spec.Name != nil {
+			name = spec.Name.Name
+		} else {
+			// We guess the name from the path, ignoring the major
+			// version suffix
+			parts := strings.Split(path, "/")
+			name = parts[len(parts)-1]
+			if len(parts) > 1 && len(name) > 1 && name[0] == 'v' && strings.Trim(name[1:], "0123456789") == "" {
+				name = parts[len(parts)-2]
+			}
+			name = strings.TrimPrefix(name, "go-")
+		}
+		imports[name] = path
+	}
+	return imports, nil
+}
+
+// HasDirective checks if the doc comment of a struct contains the
+// provided directive
+func (s *Struct) HasDirective(directive string) bool {
+	for _, d := range s.Directives {
+		if d == directive {
+			return true
+		}
+	}
+	return false
+}
+
+// newFields returns the fields declared by a line of a struct
+func newFields(field *ast.Field) ([]*Field, error) {
+	var tag reflect.StructTag
+	if field.Tag != nil {
+		value, err := strconv.Unquote(field.Tag.Value)
+		if err != nil {
+			return nil, err
+		}
+		tag = reflect.StructTag(value)
+	}
+	typeName := goTypes.ExprString(field.Type)
+
+	if len(field.Names) == 0 {
+		name := strings.TrimPrefix(typeName, "*")
+		if i := strings.LastIndex(name, "."); i != -1 {
+			name = name[i+1:]
+		}
+		return []*Field{{
+			Name:     name,
+			Type:     typeName,
+			Tag:      tag,
+			Embedded: true,
+			Exported: ast.IsExported(name),
+		}}, nil
+	}
+
+	fields := make([]*Field, len(field.Names))
+	for i, name := range field.Names {
+		fields[i] = &Field{
+			Name:     name.Name,
+			Type:     typeName,
+			Tag:      tag,
+			Exported: name.IsExported(),
+		}
+	}
+	return fields, nil
+}
+
+// Lookup returns the struct with the provided name, or nil
+func (p *Package) Lookup(name string) *Struct {
+	for _, s := range p.Structs {
+		if s.Name == name {
+			return s
+		}
+	}
+	return nil
+}
+
+// EmbeddedStruct returns the struct of the package used by an embedded
+// field, or nil if the struct is declared in another package
+func (p *Package) EmbeddedStruct(field *Field) *Struct {
+	if !field.Embedded {
+		return nil
+	}
+	return p.Lookup(strings.TrimPrefix(field.Type, "*"))
+}
+
+// IsParams checks if a struct, or one of its embedded structs, contains
+// fields using the from tag
+func (p *Package) IsParams(s *Struct) bool {
+	return p.isParams(s, map[string]bool{})
+}
+
+func (p *Package) isParams(s *Struct, visited map[string]bool) bool {
+	if visited[s.Name] {
+		return false
+	}
+	visited[s.Name] = true
+
+	for _, field := range s.Fields {
+		if embedded := p.EmbeddedStruct(field); embedded != nil {
+			if p.isParams(embedded, visited) {
+				return true
+			}
+			continue
+		}
+		if _, found := field.Tag.Lookup("from"); found {
+			return true
+		}
+	}
+	return false
+}
+
+// ParamsStructs returns all the structs of the package that are params
+// structs
+func (p *Package) ParamsStructs() []*Struct {
+	structs := []*Struct{}
+	for _, s := range p.Structs {
+		if p.IsParams(s) {
+			structs = append(structs, s)
+		}
+	}
+	return structs
+}
+
+// Param represents a param of a params struct
+type Param struct {
+	// Name contains the name of the param in the payload
+	Name string
+
+	// Field contains the struct field of the param
+	Field *Field
+
+	// Sources contains the sources of the param, in order of precedence
+	Sources []string
+
+	// Options contains the options parsed from the tags
+	Options *params.Options
+
+	// Default contains the default value of the param
+	Default string
+}
+
+// namingStrategies contains the naming strategies, by name
+var namingStrategies = map[string]params.NamingStrategy{
+	"":      nil,
+	"snake": params.SnakeCase,
+	"camel": params.CamelCase,
+	"kebab": params.KebabCase,
+}
+
+// NamingStrategy returns the naming strategy having the provided name
+// (snake, camel, or kebab). A nil strategy is returned for an empty name
+func NamingStrategy(name string) (params.NamingStrategy, error) {
+	strategy, found := namingStrategies[name]
+	if !found {
+		return nil, fmt.Errorf("unknown naming strategy %s", name)
+	}
+	return strategy, nil
+}
+
+// Params returns the params of a struct, including the ones of its
+// embedded structs. The ignored fields are skipped. The rules are the
+// same as the ones of params.Describe, the fields without sources are
+// rejected
+func (p *Package) Params(s *Struct, strategy params.NamingStrategy) ([]*Param, error) {
+	fields, err := params.DescribeStruct(structDecl{pkg: p, s: s}, strategy)
+	if err != nil {
+		return nil, err
+	}
+
+	list := make([]*Param, len(fields))
+	for i, field := range fields {
+		list[i] = &Param{
+			Name:    field.Name,
+			Field:   p.fieldByPath(s, field.Path),
+			Sources: field.Sources,
+			Options: field.Options,
+			Default: field.Default,
+		}
+	}
+	return list, nil
+}
+
+// fieldByPath returns the field having the provided path, starting
+// from s, or nil
+func (p *Package) fieldByPath(s *Struct, path []string) *Field {
+	if s == nil || len(path) == 0 {
+		return nil
+	}
+	for _, field := range s.Fields {
+		if field.Name != path[0] {
+			continue
+		}
+		if len(path) == 1 {
+			return field
+		}
+		return p.fieldByPath(p.EmbeddedStruct(field), path[1:])
+	}
+	return nil
+}
+
+// structDecl is the params.StructDecl of a struct of the package
+type structDecl struct {
+	pkg *Package
+	s   *Struct
+}
+
+// ID returns the name of the struct
+func (d structDecl) ID() string {
+	return d.s.Name
+}
+
+// Fields returns the fields of the struct. The structs declared in
+// other packages cannot be embedded
+func (d structDecl) Fields() []params.FieldDecl {
+	fields := make([]params.FieldDecl, len(d.s.Fields))
+	for i, field := range d.s.Fields {
+		fields[i] = params.FieldDecl{
+			Name: field.Name,
+			Tag:  field.Tag,
+		}
+		if embedded := d.pkg.EmbeddedStruct(field); embedded != nil {
+			fields[i].Embedded = structDecl{pkg: d.pkg, s: embedded}
+		}
+	}
+	return fields
+}
@@ -0,0 +1,115 @@
+package structscan_test
+
+import (
+	"testing"
+
+	params "github.com/Nivl/go-params"
+	"github.com/Nivl/go-params/internal/structscan"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLoad(t *testing.T) {
+	t.Parallel()
+
+	pkg, err := structscan.Load("testdata/api")
+	require.NoError(t, err, "Load() should have succeed")
+	assert.Equal(t, "api", pkg.Name, "wrong package name")
+	require.Len(t, pkg.Structs, 4, "wrong number of structs")
+	assert.Equal(t, map[string]string{"Sort": "string"}, pkg.Types, "wrong types")
+
+	pagination := pkg.Lookup("Pagination")
+	require.NotNil(t, pagination, "Pagination should have been found")
+	assert.Equal(t, "Pagination contains the params used to paginate a list", pagination.Doc, "the directives should not be part of the doc")
+	assert.True(t, pagination.HasDirective("params:generate"), "the directive should have been found")
+	assert.Equal(t, "github.com/Nivl/go-params/formfile", pagination.Imports["formfile"], "wrong imports")
+
+	list := pkg.Lookup("ListUsersParams")
+	require.NotNil(t, list, "ListUsersParams should have been found")
+	assert.False(t, list.HasDirective("params:generate"), "ListUsersParams has no directives")
+	assert.Equal(t, "ListUsersParams contains the params of the endpoint listing the users", list.Doc, "wrong doc")
+	require.Len(t, list.Fields, 2, "wrong number of fields")
+	assert.True(t, list.Fields[0].Embedded, "Pagination should be embedded")
+	assert.Equal(t, pkg.Lookup("Pagination"), pkg.EmbeddedStruct(list.Fields[0]), "wrong embedded struct")
+
+	update := pkg.Lookup("UpdateUserParams")
+	require.NotNil(t, update, "UpdateUserParams should have been found")
+	avatar := update.Fields[3]
+	assert.Equal(t, "Avatar", avatar.Name, "wrong field name")
+	assert.Equal(t, "*formfile.FormFile", avatar.Type, "wrong field type")
+	assert.Equal(t, "file", avatar.Tag.Get("from"), "wrong tag")
+	assert.False(t, update.Fields[5].Exported, "private should not be exported")
+}
+
+func TestParamsStructs(t *testing.T) {
+	t.Parallel()
+
+	pkg, err := structscan.Load("testdata/api")
+	require.NoError(t, err, "Load() should have succeed")
+
+	names := []string{}
+	for _, s := range pkg.ParamsStructs() {
+		names = append(names, s.Name)
+	}
+	assert.Equal(t, []string{"Pagination", "ListUsersParams", "UpdateUserParams"}, names, "wrong params structs")
+}
+
+func TestLoadInvalidDir(t *testing.T) {
+	t.Parallel()
+
+	_, err := structscan.Load("testdata/does-not-exist")
+	assert.Error(t, err, "Load() should have failed")
+}
+
+func TestParams(t *testing.T) {
+	t.Parallel()
+
+	pkg, err := structscan.Load("testdata/api")
+	require.NoError(t, err, "Load() should have succeed")
+
+	list, err := pkg.Params(pkg.Lookup("ListUsersParams"), params.SnakeCase)
+	require.NoError(t, err, "Params() should have succeed")
+	require.Len(t, list, 3, "wrong number of params")
+	assert.Equal(t, "page", list[0].Name, "the embedded params should come first")
+	assert.Equal(t, "1", list[0].Default, "wrong default value")
+	assert.Equal(t, []string{"perPage"}, list[1].Options.Aliases, "wrong aliases")
+	assert.Equal(t, []string{"name", "created_at"}, list[2].Options.AuthorizedValues, "wrong enum")
+
+	list, err = pkg.Params(pkg.Lookup("UpdateUserParams"), nil)
+	require.NoError(t, err, "Params() should have succeed")
+	names := []string{}
+	for _, param := range list {
+		names = append(names, param.Name)
+	}
+	assert.Equal(t, []string{"id", "name", "email", "avatar", "X-Token"}, names, "wrong params")
+	assert.Equal(t, []string{"header", "query"}, list[4].Sources, "wrong sources")
+	assert.True(t, list[0].Options.Required, "id should be required")
+}
+
+func TestParamsErrors(t *testing.T) {
+	t.Parallel()
+
+	pkg, err := structscan.Load("testdata/invalid")
+	require.NoError(t, err, "Load() should have succeed")
+
+	_, err = pkg.Params(pkg.Lookup("NoSourceParams"), nil)
+	assert.EqualError(t, err, "no source set for field Email", "Params() should have failed on a field without sources")
+
+	_, err = pkg.Params(pkg.Lookup("Node"), nil)
+	assert.EqualError(t, err, "struct Node embeds itself", "Params() should have failed on a recursive struct")
+}
+
+func TestNamingStrategy(t *testing.T) {
+	t.Parallel()
+
+	strategy, err := structscan.NamingStrategy("kebab")
+	require.NoError(t, err, "NamingStrategy() should have succeed")
+	assert.Equal(t, "user-id", strategy("UserID"), "wrong strategy")
+
+	strategy, err = structscan.NamingStrategy("")
+	require.NoError(t, err, "NamingStrategy() should have succeed")
+	assert.Nil(t, strategy, "no strategy should be returned")
+
+	_, err = structscan.NamingStrategy("pascal")
+	assert.Error(t, err, "NamingStrategy() should have failed")
+}
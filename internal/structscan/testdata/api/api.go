@@ -0,0 +1,41 @@
+package api
+
+import (
+	"github.com/Nivl/go-params/formfile"
+)
+
+// Sort represents the field used to sort a list
+type Sort string
+
+// Pagination contains the params used to paginate a list
+//
+//params:generate
+type Pagination struct {
+	Page    int `from:"query" json:"page" min_int:"1" default:"1"`
+	PerPage int `from:"query" json:"per_page" alias:"perPage" deprecated:"use per_page" max_int:"100" default:"20"`
+}
+
+type (
+	// ListUsersParams contains the params of the endpoint listing the users
+	ListUsersParams struct {
+		Pagination
+		Sort string `from:"query" json:"sort" enum:"name,created_at"`
+	}
+
+	// UpdateUserParams contains the params of the endpoint updating a user
+	UpdateUserParams struct {
+		ID      string             `from:"url" json:"id" params:"uuid,required"`
+		Name    *string            `from:"form" json:"name" params:"trim,noempty" maxlen:"255"`
+		Email   string             `from:"form" json:"email" params:"email"`
+		Avatar  *formfile.FormFile `from:"file" json:"avatar" params:"image" max_size:"5MB"`
+		Token   string             `from:"header,query" json:"X-Token"`
+		private string             `json:"-"`
+		Ignored string             `from:"form" json:"-"`
+	}
+)
+
+// user is not a params struct
+type user struct {
+	ID   string `json:"id"`
+	Name string `json:"name"`
+}
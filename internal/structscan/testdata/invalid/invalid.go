@@ -0,0 +1,13 @@
+package invalid
+
+// NoSourceParams contains a field without sources
+type NoSourceParams struct {
+	Name  string `from:"form" json:"name"`
+	Email string `json:"email"`
+}
+
+// Node embeds itself
+type Node struct {
+	*Node
+	Name string `from:"query" json:"name"`
+}
@@ -0,0 +1,47 @@
+package params
+
+import (
+	"github.com/Nivl/go-types/slices"
+)
+
+// jsonSchemaDraft is the URI of the JSON Schema version generated
+const jsonSchemaDraft = "https://json-schema.org/draft/2020-12/schema"
+
+// JSONSchema generates a JSON Schema (draft 2020-12) of the form params
+// of T. T needs to be a struct, ErrNotStructPointer is returned otherwise
+func JSONSchema[T any]() (*Schema, error) {
+	return New(new(T)).JSONSchema()
+}
+
+// JSONSchema generates a JSON Schema (draft 2020-12) of the form params
+// of the struct. The params of the embedded structs are part of the schema
+func (p *Params) JSONSchema() (*Schema, error) {
+	target, err := p.target(true)
+	if err != nil {
+		return nil, err
+	}
+	fields, err := p.describeFields(target.Type())
+	if err != nil {
+		return nil, err
+	}
+
+	schema := &Schema{
+		SchemaURI:  jsonSchemaDraft,
+		Type:       "object",
+		Properties: map[string]*Schema{},
+	}
+	for _, field := range fields {
+		if !slices.HasString("form", field.Sources) {
+			continue
+		}
+
+		fieldSchema := newFieldSchema(field)
+		for _, key := range field.Options.Keys() {
+			schema.Properties[key] = keySchema(field, fieldSchema, key)
+		}
+		if field.Options.Required {
+			schema.Required = append(schema.Required, field.Name)
+		}
+	}
+	return schema, nil
+}
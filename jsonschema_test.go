@@ -0,0 +1,76 @@
+package params_test
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+
+	params "github.com/Nivl/go-params"
+	"github.com/Nivl/go-params/formfile"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type jsonSchemaAuthor struct {
+	AuthorID string `from:"form" json:"author_id" params:"uuid,required"`
+}
+
+type jsonSchemaParams struct {
+	*jsonSchemaAuthor
+	ID      string             `from:"url" json:"id"`
+	Title   string             `from:"form,query" json:"title" params:"required" maxlen:"100"`
+	Slug    string             `from:"form" json:"slug" params:"slug" alias:"permalink" deprecated:"use slug"`
+	Email   string             `from:"form" json:"email" params:"email"`
+	Website string             `from:"form" json:"website" params:"url"`
+	Rating  int                `from:"form" json:"rating" min_int:"1" max_int:"5" default:"3"`
+	Tags    []string           `from:"form" json:"tags" min_items:"1" max_items:"10" enum:"go,rust"`
+	Cover   *formfile.FormFile `from:"file" json:"cover"`
+	Ignored string             `from:"form" json:"-"`
+}
+
+func TestJSONSchema(t *testing.T) {
+	t.Parallel()
+
+	expected := `{
+		"$schema": "https://json-schema.org/draft/2020-12/schema",
+		"type": "object",
+		"required": ["author_id", "title"],
+		"properties": {
+			"author_id": {
+				"type": "string",
+				"format": "uuid",
+				"pattern": "^[a-f0-9]{8}-[a-f0-9]{4}-4[a-f0-9]{3}-[89abAB][a-f0-9]{3}-[a-f0-9]{12}$"
+			},
+			"title": {"type": "string", "maxLength": 100},
+			"slug": {"type": "string", "pattern": "^[a-z0-9]([a-z0-9_-]*[a-z0-9])?$"},
+			"permalink": {
+				"type": "string",
+				"pattern": "^[a-z0-9]([a-z0-9_-]*[a-z0-9])?$",
+				"deprecated": true,
+				"description": "use slug"
+			},
+			"email": {"type": "string", "format": "email"},
+			"website": {"type": "string", "format": "uri"},
+			"rating": {"type": "integer", "minimum": 1, "maximum": 5, "default": 3},
+			"tags": {
+				"type": "array",
+				"minItems": 1,
+				"maxItems": 10,
+				"items": {"type": "string", "enum": ["go", "rust"]}
+			}
+		}
+	}`
+
+	schema, err := params.JSONSchema[jsonSchemaParams]()
+	require.NoError(t, err, "JSONSchema() should have succeed")
+	output, err := json.Marshal(schema)
+	require.NoError(t, err, "the schema should be marshalable")
+	assert.JSONEq(t, expected, string(output), "unexpected schema")
+}
+
+func TestJSONSchemaInvalidType(t *testing.T) {
+	t.Parallel()
+
+	_, err := params.JSONSchema[string]()
+	assert.True(t, errors.Is(err, params.ErrNotStructPointer), "JSONSchema() should have failed")
+}
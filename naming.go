@@ -0,0 +1,97 @@
+package params
+
+import (
+	"reflect"
+	"strings"
+	"unicode"
+)
+
+// defaultTagKey is the default key of the tag used to name and ignore
+// the fields
+const defaultTagKey = "param"
+
+// tagKey contains the key of the tag used to name and ignore the fields.
+// The json tag is used by the fields that don't have this tag
+var tagKey = defaultTagKey
+
+// SetTagKey sets the key of the tag used to name and ignore the fields
+// ("param" by default). The json tag is used by the fields that don't have
+// this tag.
+// SetTagKey is not safe for concurrent use and should be called before
+// parsing anything, like in an init() function
+func SetTagKey(key string) {
+	tagKey = key
+}
+
+// nameTagOptions returns the options of the tag used to name a field
+func nameTagOptions(tags *reflect.StructTag) []string {
+	value, found := tags.Lookup(tagKey)
+	if !found {
+		value = tags.Get("json")
+	}
+	return strings.Split(value, ",")
+}
+
+// NamingStrategy is a function used to generate the name of a param from
+// the name of its Go field, when no names are set in the tags
+type NamingStrategy func(fieldName string) string
+
+// SnakeCase is a NamingStrategy converting UserID to user_id
+func SnakeCase(fieldName string) string {
+	return strings.Join(lowerWords(fieldName), "_")
+}
+
+// KebabCase is a NamingStrategy converting UserID to user-id
+func KebabCase(fieldName string) string {
+	return strings.Join(lowerWords(fieldName), "-")
+}
+
+// CamelCase is a NamingStrategy converting UserID to userId
+func CamelCase(fieldName string) string {
+	words := lowerWords(fieldName)
+	for i := 1; i < len(words); i++ {
+		words[i] = strings.ToUpper(words[i][:1]) + words[i][1:]
+	}
+	return strings.Join(words, "")
+}
+
+// lowerWords returns the lowercased words of a Go identifier
+func lowerWords(fieldName string) []string {
+	words := splitWords(fieldName)
+	for i, w := range words {
+		words[i] = strings.ToLower(w)
+	}
+	return words
+}
+
+// splitWords splits a Go identifier into words. Acronyms are kept
+// together, so HTTPServerID gives HTTP, Server, ID
+func splitWords(fieldName string) []string {
+	words := []string{}
+	runes := []rune(fieldName)
+	start := 0
+	for i := 1; i < len(runes); i++ {
+		prev, cur := runes[i-1], runes[i]
+		newWord := false
+		switch {
+		case cur == '_':
+			newWord = true
+		case unicode.IsUpper(cur) && (unicode.IsLower(prev) || unicode.IsDigit(prev)):
+			// userID
+			newWord = true
+		case unicode.IsUpper(cur) && unicode.IsUpper(prev) && i+1 < len(runes) && unicode.IsLower(runes[i+1]):
+			// HTTPServer
+			newWord = true
+		}
+		if newWord {
+			if w := strings.Trim(string(runes[start:i]), "_"); w != "" {
+				words = append(words, w)
+			}
+			start = i
+		}
+	}
+	if w := strings.Trim(string(runes[start:]), "_"); w != "" {
+		words = append(words, w)
+	}
+	return words
+}
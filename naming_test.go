@@ -0,0 +1,34 @@
+package params_test
+
+import (
+	"testing"
+
+	params "github.com/Nivl/go-params"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNamingStrategies(t *testing.T) {
+	testCases := []struct {
+		fieldName     string
+		expectedSnake string
+		expectedCamel string
+		expectedKebab string
+	}{
+		{"Name", "name", "name", "name"},
+		{"UserID", "user_id", "userId", "user-id"},
+		{"HTTPServerID", "http_server_id", "httpServerId", "http-server-id"},
+		{"PerPage", "per_page", "perPage", "per-page"},
+		{"Page2Size", "page2_size", "page2Size", "page2-size"},
+		{"Already_Snake", "already_snake", "alreadySnake", "already-snake"},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.fieldName, func(t *testing.T) {
+			t.Parallel()
+			assert.Equal(t, tc.expectedSnake, params.SnakeCase(tc.fieldName), "SnakeCase() returned an unexpected value")
+			assert.Equal(t, tc.expectedCamel, params.CamelCase(tc.fieldName), "CamelCase() returned an unexpected value")
+			assert.Equal(t, tc.expectedKebab, params.KebabCase(tc.fieldName), "KebabCase() returned an unexpected value")
+		})
+	}
+}
@@ -0,0 +1,192 @@
+package params
+
+import (
+	"fmt"
+	"strings"
+)
+
+// openAPILocations contains the OpenAPI location of the sources that
+// are documented as parameters
+var openAPILocations = map[string]string{
+	"url":    "path",
+	"query":  "query",
+	"header": "header",
+	"cookie": "cookie",
+}
+
+// OpenAPIOperation contains the parts of an OpenAPI 3 operation object
+// generated from a params struct
+type OpenAPIOperation struct {
+	Parameters  []*OpenAPIParameter `json:"parameters,omitempty"`
+	RequestBody *OpenAPIRequestBody `json:"requestBody,omitempty"`
+}
+
+// OpenAPIParameter represents an OpenAPI 3 parameter object
+type OpenAPIParameter struct {
+	Name        string  `json:"name"`
+	In          string  `json:"in"`
+	Description string  `json:"description,omitempty"`
+	Required    bool    `json:"required,omitempty"`
+	Deprecated  bool    `json:"deprecated,omitempty"`
+	Schema      *Schema `json:"schema"`
+}
+
+// OpenAPIRequestBody represents an OpenAPI 3 request body object
+type OpenAPIRequestBody struct {
+	Required bool                         `json:"required,omitempty"`
+	Content  map[string]*OpenAPIMediaType `json:"content"`
+}
+
+// OpenAPIMediaType represents an OpenAPI 3 media type object
+type OpenAPIMediaType struct {
+	Schema   *Schema                     `json:"schema"`
+	Encoding map[string]*OpenAPIEncoding `json:"encoding,omitempty"`
+}
+
+// OpenAPIEncoding represents an OpenAPI 3 encoding object
+type OpenAPIEncoding struct {
+	ContentType string `json:"contentType"`
+}
+
+// OpenAPI generates the OpenAPI 3 parameters (url, query, header) and the
+// request body (form, file) of the params struct
+func (p *Params) OpenAPI() (*OpenAPIOperation, error) {
+	target, err := p.target(true)
+	if err != nil {
+		return nil, err
+	}
+	fields, err := p.describeFields(target.Type())
+	if err != nil {
+		return nil, err
+	}
+
+	op := &OpenAPIOperation{}
+	body := &Schema{
+		Type:       "object",
+		Properties: map[string]*Schema{},
+	}
+	encoding := map[string]*OpenAPIEncoding{}
+	hasFiles := false
+
+	for _, field := range fields {
+		fieldSchema := newFieldSchema(field)
+		// A required param having several sources can be sent using any
+		// of them, so none of them is required
+		required := field.Options.Required && len(field.Sources) == 1
+		fallback := ""
+		if field.Options.Required && !required {
+			fallback = requiredSourcesDescription(field.Sources)
+		}
+		for _, source := range field.Sources {
+			for _, key := range field.Options.Keys() {
+				isName := key == field.Name
+				deprecated := field.Options.IsDeprecatedKey(key)
+				schema := keySchema(field, fieldSchema, key)
+
+				switch source {
+				case "form", "file":
+					if isName && fallback != "" {
+						documented := *schema
+						documented.Description = joinDescriptions(schema.Description, fallback)
+						schema = &documented
+					}
+					body.Properties[key] = schema
+					if isName && required {
+						body.Required = append(body.Required, key)
+					}
+					if source == "file" {
+						hasFiles = true
+						if contentType := fileContentType(field.Options); contentType != "" {
+							encoding[key] = &OpenAPIEncoding{ContentType: contentType}
+						}
+					}
+				default:
+					in, found := openAPILocations[source]
+					if !found {
+						return nil, fmt.Errorf("source %s for field %s cannot be documented", source, field.GoName)
+					}
+					// aliases cannot be used in a path
+					if in == "path" && !isName {
+						continue
+					}
+					description := ""
+					if deprecated {
+						description = field.Options.Deprecated
+					}
+					if isName && fallback != "" {
+						description = joinDescriptions(description, fallback)
+					}
+					param := &OpenAPIParameter{
+						Name: key,
+						In:   in,
+						// path params are always required
+						Required:    (isName && required) || in == "path",
+						Deprecated:  deprecated,
+						Description: description,
+						Schema:      fieldSchema,
+					}
+					op.Parameters = append(op.Parameters, param)
+				}
+			}
+		}
+	}
+
+	if len(body.Properties) > 0 {
+		op.RequestBody = &OpenAPIRequestBody{
+			Required: len(body.Required) > 0,
+			Content:  map[string]*OpenAPIMediaType{},
+		}
+		if hasFiles {
+			mediaType := &OpenAPIMediaType{Schema: body}
+			if len(encoding) > 0 {
+				mediaType.Encoding = encoding
+			}
+			op.RequestBody.Content["multipart/form-data"] = mediaType
+		} else {
+			op.RequestBody.Content["application/x-www-form-urlencoded"] = &OpenAPIMediaType{Schema: body}
+			op.RequestBody.Content["application/json"] = &OpenAPIMediaType{Schema: body}
+		}
+	}
+	return op, nil
+}
+
+// requiredSourcesDescription documents a required param that can be sent
+// using several sources
+func requiredSourcesDescription(sources []string) string {
+	locations := make([]string, len(sources))
+	for i, source := range sources {
+		switch source {
+		case "form", "file":
+			locations[i] = "body"
+		default:
+			locations[i] = source
+			if in, found := openAPILocations[source]; found {
+				locations[i] = in
+			}
+		}
+	}
+	return "Required in the " + strings.Join(locations, " or the ")
+}
+
+// joinDescriptions joins two descriptions, ignoring the empty ones
+func joinDescriptions(a, b string) string {
+	if a == "" {
+		return b
+	}
+	if b == "" {
+		return a
+	}
+	return a + ". " + b
+}
+
+// fileContentType returns the content types accepted by a file, using
+// the format of the OpenAPI encoding object
+func fileContentType(opts *Options) string {
+	if len(opts.AuthorizedMimes) > 0 {
+		return strings.Join(opts.AuthorizedMimes, ", ")
+	}
+	if opts.ValidateImage {
+		return "image/*"
+	}
+	return ""
+}
@@ -0,0 +1,147 @@
+package params_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	params "github.com/Nivl/go-params"
+	"github.com/Nivl/go-params/formfile"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestOpenAPI(t *testing.T) {
+	t.Run("parameters", subTestOpenAPIParameters)
+	t.Run("urlencoded body", subTestOpenAPIURLEncodedBody)
+	t.Run("multipart body", subTestOpenAPIMultipartBody)
+	t.Run("invalid source", subTestOpenAPIInvalidSource)
+}
+
+func subTestOpenAPIParameters(t *testing.T) {
+	t.Parallel()
+
+	type embedded struct {
+		Token string `from:"header,query" json:"X-Token"`
+	}
+	s := struct {
+		embedded
+		ID      string `from:"url" json:"id" params:"uuid"`
+		PerPage int    `from:"query" json:"per_page" alias:"perPage" deprecated:"use per_page" min_int:"1" max_int:"100" default:"10"`
+		Sort    string `from:"query" json:"sort" enum:"asc,desc" params:"required"`
+		Ignored string `from:"query" json:"-"`
+		Key     string `from:"header,query" json:"api_key" params:"required"`
+	}{}
+
+	op, err := params.New(s).OpenAPI()
+	require.NoError(t, err, "OpenAPI() should have succeed")
+	assert.Nil(t, op.RequestBody, "there should be no request body")
+
+	expected := `[
+		{"name": "X-Token", "in": "header", "schema": {"type": "string"}},
+		{"name": "X-Token", "in": "query", "schema": {"type": "string"}},
+		{"name": "id", "in": "path", "required": true, "schema": {
+			"type": "string",
+			"format": "uuid",
+			"pattern": "^[a-f0-9]{8}-[a-f0-9]{4}-4[a-f0-9]{3}-[89abAB][a-f0-9]{3}-[a-f0-9]{12}$"
+		}},
+		{"name": "per_page", "in": "query", "schema": {"type": "integer", "minimum": 1, "maximum": 100, "default": 10}},
+		{"name": "perPage", "in": "query", "description": "use per_page", "deprecated": true, "schema": {"type": "integer", "minimum": 1, "maximum": 100, "default": 10}},
+		{"name": "sort", "in": "query", "required": true, "schema": {"type": "string", "enum": ["asc", "desc"]}},
+		{"name": "api_key", "in": "header", "description": "Required in the header or the query", "schema": {"type": "string"}},
+		{"name": "api_key", "in": "query", "description": "Required in the header or the query", "schema": {"type": "string"}}
+	]`
+	output, err := json.Marshal(op.Parameters)
+	require.NoError(t, err, "the parameters should be marshalable")
+	assert.JSONEq(t, expected, string(output), "unexpected parameters")
+}
+
+func subTestOpenAPIURLEncodedBody(t *testing.T) {
+	t.Parallel()
+
+	s := &struct {
+		Name    string   `from:"form" json:"name" params:"required,trim" maxlen:"255"`
+		Email   *string  `from:"form" json:"email" params:"email,noempty"`
+		Website string   `from:"form" json:"website" params:"url"`
+		Public  bool     `from:"form" json:"public" default:"true"`
+		Tags    []string `from:"form" json:"tags" params:"slug" min_items:"1" max_items:"5"`
+		Lang    string   `from:"form,query" json:"lang" params:"required"`
+	}{}
+
+	op, err := params.New(s).OpenAPI()
+	require.NoError(t, err, "OpenAPI() should have succeed")
+	require.Len(t, op.Parameters, 1, "lang should be a query parameter")
+	assert.False(t, op.Parameters[0].Required, "lang can be sent in the body")
+	require.NotNil(t, op.RequestBody, "there should be a request body")
+	assert.True(t, op.RequestBody.Required, "the body should be required")
+
+	expected := `{
+		"type": "object",
+		"required": ["name"],
+		"properties": {
+			"name": {"type": "string", "maxLength": 255},
+			"email": {"type": "string", "format": "email", "minLength": 1},
+			"website": {"type": "string", "format": "uri"},
+			"public": {"type": "boolean", "default": true},
+			"tags": {
+				"type": "array",
+				"minItems": 1,
+				"maxItems": 5,
+				"items": {"type": "string", "pattern": "^[a-z0-9]([a-z0-9_-]*[a-z0-9])?$"}
+			},
+			"lang": {"type": "string", "description": "Required in the body or the query"}
+		}
+	}`
+	require.Len(t, op.RequestBody.Content, 2, "the body should have 2 content types")
+	for _, contentType := range []string{"application/x-www-form-urlencoded", "application/json"} {
+		mediaType, found := op.RequestBody.Content[contentType]
+		require.True(t, found, "%s should be accepted", contentType)
+		output, err := json.Marshal(mediaType.Schema)
+		require.NoError(t, err, "the schema should be marshalable")
+		assert.JSONEq(t, expected, string(output), "unexpected schema")
+	}
+}
+
+func subTestOpenAPIMultipartBody(t *testing.T) {
+	t.Parallel()
+
+	s := &struct {
+		Title   string               `from:"form" json:"title"`
+		Picture *formfile.FormFile   `from:"file" json:"picture" params:"image"`
+		Files   []*formfile.FormFile `from:"file" json:"files" mime:"application/pdf,text/plain" max_items:"3"`
+	}{}
+
+	op, err := params.New(s).OpenAPI()
+	require.NoError(t, err, "OpenAPI() should have succeed")
+	require.NotNil(t, op.RequestBody, "there should be a request body")
+	assert.False(t, op.RequestBody.Required, "the body should not be required")
+
+	expected := `{
+		"schema": {
+			"type": "object",
+			"properties": {
+				"title": {"type": "string"},
+				"picture": {"type": "string", "format": "binary"},
+				"files": {"type": "array", "maxItems": 3, "items": {"type": "string", "format": "binary"}}
+			}
+		},
+		"encoding": {
+			"picture": {"contentType": "image/*"},
+			"files": {"contentType": "application/pdf, text/plain"}
+		}
+	}`
+	require.Len(t, op.RequestBody.Content, 1, "the body should have 1 content type")
+	output, err := json.Marshal(op.RequestBody.Content["multipart/form-data"])
+	require.NoError(t, err, "the media type should be marshalable")
+	assert.JSONEq(t, expected, string(output), "unexpected media type")
+}
+
+func subTestOpenAPIInvalidSource(t *testing.T) {
+	t.Parallel()
+
+	s := &struct {
+		Name string `from:"session" json:"name"`
+	}{}
+
+	_, err := params.New(s).OpenAPI()
+	assert.Error(t, err, "OpenAPI() should have failed")
+}
@@ -1,7 +1,16 @@
 package params
 
 import (
+	"crypto/md5" //nolint:gosec // used to identify files, not for security
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
 	"io"
+	"mime"
+	"mime/multipart"
+	"net/http"
+	"net/url"
+	"path/filepath"
 	"reflect"
 	"strconv"
 	"strings"
@@ -41,14 +50,19 @@ type Options struct {
 	// maxlen:"255"
 	MaxLen int
 
-	// Name contains the name of the field in the payload
-	// json:"my_field"
+	// Name contains the name of the field in the payload. The param tag
+	// has the precedence over the json tag
+	// param:"my_field" or json:"my_field"
 	Name string
 
 	// Ignore means the field should not been parsed
-	// json:"-"
+	// param:"-" or json:"-"
 	Ignore bool
 
+	// OmitEmpty means the field should not be extracted if it has a zero value
+	// param:"my_field,omitempty"
+	OmitEmpty bool
+
 	// Required means the request should fail with a Bad Request if the field is missing.
 	// params:"required"
 	Required bool
@@ -92,6 +106,175 @@ type Options struct {
 	// NoEmptyItems means all items of an array needs to have a value
 	//params:"no_empty_items"
 	NoEmptyItems bool
+
+	// MaxSize represents the maximum size (in bytes) of a file
+	// max_size:"5MB"
+	MaxSize *int64
+
+	// MinSize represents the minimum size (in bytes) of a file
+	// min_size:"1KB"
+	MinSize *int64
+
+	// AuthorizedMimes represents the list of mime types accepted for a file.
+	// A wildcard can be used as subtype to accept a whole family of types
+	// mime:"image/*,application/pdf"
+	AuthorizedMimes []string
+
+	// MinWidth represents the minimum width (in pixels) of an image
+	// min_width:"256"
+	MinWidth *int
+
+	// MaxWidth represents the maximum width (in pixels) of an image
+	// max_width:"1024"
+	MaxWidth *int
+
+	// MinHeight represents the minimum height (in pixels) of an image
+	// min_height:"256"
+	MinHeight *int
+
+	// MaxHeight represents the maximum height (in pixels) of an image
+	// max_height:"1024"
+	MaxHeight *int
+
+	// AspectRatio represents the aspect ratio an image needs to have
+	// aspect:"16:9"
+	AspectRatio *Ratio
+
+	// AuthorizedExtensions represents the list of extensions accepted
+	// for the name of a file
+	// ext:"png,jpg"
+	AuthorizedExtensions []string
+
+	// StrictMime means the extension of a file and the content type sent by
+	// the client need to match the type detected from the content of the file
+	// params:"strict_mime"
+	StrictMime bool
+
+	// HashSHA256 means the SHA-256 digest of a file needs to be computed
+	// hash:"sha256"
+	HashSHA256 bool
+
+	// HashMD5 means the MD5 digest of a file needs to be computed
+	// hash:"md5"
+	HashMD5 bool
+
+	// ChecksumField contains the name of the param holding the checksum a
+	// file needs to match. The algorithm is guessed from the length of the
+	// checksum (MD5 or SHA-256)
+	// checksum_field:"file_sha256"
+	ChecksumField string
+
+	// Inspectors contains the name of the FileInspectors to run on a file
+	// inspect:"antivirus,policy"
+	Inspectors []string
+
+	// ConflictError means the request should fail if multiple sources
+	// provide different values for the field
+	// conflict:"error"
+	ConflictError bool
+
+	// Aliases contains alternative keys that can be used to send the param
+	// alias:"perPage,page_size"
+	Aliases []string
+
+	// Deprecated contains the warning to record when the param is sent.
+	// If the field has aliases, the warning is only recorded when an
+	// alias is used
+	// deprecated:"use per_page"
+	Deprecated string
+}
+
+// Keys returns all the keys that can be used to send the param, starting
+// with its name
+func (opts *Options) Keys() []string {
+	return append([]string{opts.Name}, opts.Aliases...)
+}
+
+// LookupKey returns the first key of the param found in the source.
+// The name of the param is returned if none of the keys are found
+func (opts *Options) LookupKey(source url.Values) (key string, found bool) {
+	for _, k := range opts.Keys() {
+		if _, found := source[k]; found {
+			return k, true
+		}
+	}
+	return opts.Name, false
+}
+
+// IsDeprecatedKey checks if a warning needs to be recorded when the param
+// is sent using the provided key
+func (opts *Options) IsDeprecatedKey(key string) bool {
+	if opts.Deprecated == "" {
+		return false
+	}
+	return len(opts.Aliases) == 0 || !strings.EqualFold(key, opts.Name)
+}
+
+// ValidateConflict makes sure two sources sending the param use the same
+// values, when conflict:"error" is set
+func (opts *Options) ValidateConflict(values, otherValues []string) error {
+	if opts.ConflictError && !equalValues(values, otherValues) {
+		return perror.New(opts.Name, ErrMsgConflictingValues)
+	}
+	return nil
+}
+
+// Ratio represents an aspect ratio, like 16:9
+type Ratio struct {
+	Width  int
+	Height int
+}
+
+// parseRatio parses a ratio using the "width:height" format
+func parseRatio(ratio string) (*Ratio, error) {
+	parts := strings.Split(ratio, ":")
+	if len(parts) != 2 {
+		return nil, fmt.Errorf("invalid ratio %s", ratio)
+	}
+
+	width, err := strconv.Atoi(strings.TrimSpace(parts[0]))
+	if err != nil {
+		return nil, err
+	}
+	height, err := strconv.Atoi(strings.TrimSpace(parts[1]))
+	if err != nil {
+		return nil, err
+	}
+	if width <= 0 || height <= 0 {
+		return nil, fmt.Errorf("invalid ratio %s", ratio)
+	}
+	return &Ratio{Width: width, Height: height}, nil
+}
+
+// sizeUnits contains the multiplier of all the units accepted in a size
+var sizeUnits = map[string]float64{
+	"":   1,
+	"B":  1,
+	"KB": 1 << 10,
+	"MB": 1 << 20,
+	"GB": 1 << 30,
+}
+
+// parseSize parses a human readable size (5MB, 512KB, 1024, ...) and
+// returns its value in bytes
+func parseSize(size string) (int64, error) {
+	size = strings.ToUpper(strings.TrimSpace(size))
+	numberEnd := strings.IndexFunc(size, func(r rune) bool {
+		return (r < '0' || r > '9') && r != '.'
+	})
+	if numberEnd == -1 {
+		numberEnd = len(size)
+	}
+
+	multiplier, ok := sizeUnits[strings.TrimSpace(size[numberEnd:])]
+	if !ok {
+		return 0, fmt.Errorf("unknown unit in size %s", size)
+	}
+	v, err := strconv.ParseFloat(size[:numberEnd], 64)
+	if err != nil {
+		return 0, err
+	}
+	return int64(v * multiplier), nil
 }
 
 // NewOptions returns a ParamOptions from a StructTag
@@ -99,14 +282,16 @@ func NewOptions(tags *reflect.StructTag) (*Options, error) {
 	output := &Options{}
 	var err error
 
-	// We use the json tag to get the field name
-	jsonOpts := strings.Split(tags.Get("json"), ",")
-	if len(jsonOpts) > 0 {
-		if jsonOpts[0] == "-" {
+	// We use the param tag (or the json tag as fallback) to get the
+	// field name
+	nameOpts := nameTagOptions(tags)
+	if len(nameOpts) > 0 {
+		if nameOpts[0] == "-" {
 			return &Options{Ignore: true}, nil
 		}
 
-		output.Name = jsonOpts[0]
+		output.Name = nameOpts[0]
+		output.OmitEmpty = slices.HasString("omitempty", nameOpts[1:])
 	}
 
 	// We use the maxlen tag to get the max length of a the value
@@ -163,6 +348,131 @@ func NewOptions(tags *reflect.StructTag) (*Options, error) {
 		output.MaxItems = ptrs.NewInt(v)
 	}
 
+	// We use the max_size tag to get the max size of a file
+	maxSize := tags.Get("max_size")
+	if len(maxSize) > 0 {
+		v, err := parseSize(maxSize)
+		if err != nil {
+			return nil, perror.New(output.Name, ErrMsgInvalidSize)
+		}
+		output.MaxSize = &v
+	}
+
+	// We use the min_size tag to get the min size of a file
+	minSize := tags.Get("min_size")
+	if len(minSize) > 0 {
+		v, err := parseSize(minSize)
+		if err != nil {
+			return nil, perror.New(output.Name, ErrMsgInvalidSize)
+		}
+		output.MinSize = &v
+	}
+
+	// We use the mime tag to get all the mime types a file can have
+	mimeTypes := tags.Get("mime")
+	if len(mimeTypes) > 0 {
+		output.AuthorizedMimes = strings.Split(mimeTypes, ",")
+	}
+
+	// We use the ext tag to get all the extensions a file can have
+	extensions := tags.Get("ext")
+	if len(extensions) > 0 {
+		output.AuthorizedExtensions = strings.Split(extensions, ",")
+	}
+
+	// We use the min_width tag to get the min width of an image
+	minWidth := tags.Get("min_width")
+	if len(minWidth) > 0 {
+		v, err := strconv.Atoi(minWidth)
+		if err != nil {
+			return nil, perror.New(output.Name, ErrMsgInvalidInteger)
+		}
+		output.MinWidth = ptrs.NewInt(v)
+	}
+
+	// We use the max_width tag to get the max width of an image
+	maxWidth := tags.Get("max_width")
+	if len(maxWidth) > 0 {
+		v, err := strconv.Atoi(maxWidth)
+		if err != nil {
+			return nil, perror.New(output.Name, ErrMsgInvalidInteger)
+		}
+		output.MaxWidth = ptrs.NewInt(v)
+	}
+
+	// We use the min_height tag to get the min height of an image
+	minHeight := tags.Get("min_height")
+	if len(minHeight) > 0 {
+		v, err := strconv.Atoi(minHeight)
+		if err != nil {
+			return nil, perror.New(output.Name, ErrMsgInvalidInteger)
+		}
+		output.MinHeight = ptrs.NewInt(v)
+	}
+
+	// We use the max_height tag to get the max height of an image
+	maxHeight := tags.Get("max_height")
+	if len(maxHeight) > 0 {
+		v, err := strconv.Atoi(maxHeight)
+		if err != nil {
+			return nil, perror.New(output.Name, ErrMsgInvalidInteger)
+		}
+		output.MaxHeight = ptrs.NewInt(v)
+	}
+
+	// We use the aspect tag to get the aspect ratio of an image
+	aspect := tags.Get("aspect")
+	if len(aspect) > 0 {
+		if output.AspectRatio, err = parseRatio(aspect); err != nil {
+			return nil, perror.New(output.Name, ErrMsgInvalidRatio)
+		}
+	}
+
+	// We use the hash tag to get the digests to compute for a file
+	hashes := tags.Get("hash")
+	if len(hashes) > 0 {
+		for _, h := range strings.Split(hashes, ",") {
+			switch strings.ToLower(strings.TrimSpace(h)) {
+			case "sha256":
+				output.HashSHA256 = true
+			case "md5":
+				output.HashMD5 = true
+			default:
+				return nil, perror.New(output.Name, ErrMsgInvalidHash)
+			}
+		}
+	}
+
+	// We use the checksum_field tag to get the param containing
+	// the checksum of a file
+	output.ChecksumField = tags.Get("checksum_field")
+
+	// We use the inspect tag to get the inspectors to run on a file
+	inspectors := tags.Get("inspect")
+	if len(inspectors) > 0 {
+		output.Inspectors = strings.Split(inspectors, ",")
+	}
+
+	// We use the alias tag to get the alternative keys of the param
+	aliases := tags.Get("alias")
+	if len(aliases) > 0 {
+		output.Aliases = strings.Split(aliases, ",")
+	}
+
+	// We use the deprecated tag to get the warning to record when
+	// the param is used
+	output.Deprecated = tags.Get("deprecated")
+
+	// We use the conflict tag to know what to do when multiple sources
+	// provide a value
+	switch tags.Get("conflict") {
+	case "", "first":
+	case "error":
+		output.ConflictError = true
+	default:
+		return nil, perror.New(output.Name, ErrMsgInvalidConflict)
+	}
+
 	// We parse the params
 	opts := strings.Split(tags.Get("params"), ",")
 	nbOptions := len(opts)
@@ -188,6 +498,8 @@ func NewOptions(tags *reflect.StructTag) (*Options, error) {
 			output.ValidateImage = true
 		case "no_empty_items":
 			output.NoEmptyItems = true
+		case "strict_mime":
+			output.StrictMime = true
 		}
 	}
 	return output, nil
@@ -223,6 +535,23 @@ func (opts *Options) ValidateSlice(values []string, wasProvided bool) error {
 	return nil
 }
 
+// ValidateFileCount checks the given number of files passes the options set
+func (opts *Options) ValidateFileCount(count int) error {
+	if count == 0 && opts.Required {
+		return perror.New(opts.Name, ErrMsgMissingParameter)
+	}
+
+	if opts.MinItems != nil && count < *opts.MinItems {
+		return perror.New(opts.Name, ErrMsgArrayTooSmall)
+	}
+
+	if opts.MaxItems != nil && count > *opts.MaxItems {
+		return perror.New(opts.Name, ErrMsgArrayTooBig)
+	}
+
+	return nil
+}
+
 // Validate checks the given value passes the options set
 func (opts *Options) Validate(value string, wasProvided, isArrayItem bool) error {
 	if opts.MaxLen > 0 && len(value) > opts.MaxLen {
@@ -310,7 +639,7 @@ func (opts *Options) ValidateFileContent(file io.ReadSeeker) (mimeType string, e
 
 	if !opts.ValidateImage {
 		// We still get the mimetype
-		mimeType, err = filetype.MimeType(file)
+		mimeType, err = detectMimeType(file)
 		if err != nil {
 			return "", err
 		}
@@ -331,6 +660,203 @@ func (opts *Options) ValidateFileContent(file io.ReadSeeker) (mimeType string, e
 	return mimeType, nil
 }
 
+// detectMimeType returns the mime type of a file using the first 512
+// bytes of its content. Only the bytes actually read are sniffed, a
+// zero-padded buffer would make any small text file look binary.
+// io.EOF is returned if the file is empty
+func detectMimeType(file io.Reader) (string, error) {
+	buf := make([]byte, 512)
+	n, err := io.ReadFull(file, buf)
+	if err != nil && err != io.ErrUnexpectedEOF {
+		return "", err
+	}
+	return http.DetectContentType(buf[:n]), nil
+}
+
+// hasDimensionConstraints returns whether the options contain rules
+// about the dimensions of an image
+func (opts *Options) hasDimensionConstraints() bool {
+	return opts.MinWidth != nil || opts.MaxWidth != nil ||
+		opts.MinHeight != nil || opts.MaxHeight != nil ||
+		opts.AspectRatio != nil
+}
+
+// ValidateImageDimensions reads the dimensions of the given image and checks
+// they pass the options set. Files that are not images, or that cannot be
+// decoded, have a dimension of 0x0 and are only rejected if the options
+// contain rules about the dimensions
+func (opts *Options) ValidateImageDimensions(file io.ReadSeeker, mimeType string) (width, height int, err error) {
+	// Just for security, but it shouldn't be necessary
+	defer func() {
+		_, seekErr := file.Seek(0, io.SeekStart)
+		if err == nil {
+			err = seekErr
+		}
+	}()
+
+	hasConstraints := opts.hasDimensionConstraints()
+	if !strings.HasPrefix(mimeType, "image/") {
+		if hasConstraints {
+			return 0, 0, perror.New(opts.Name, ErrMsgInvalidImage)
+		}
+		return 0, 0, nil
+	}
+
+	width, height, err = imageDimensions(file)
+	if err != nil {
+		if hasConstraints {
+			return 0, 0, perror.New(opts.Name, ErrMsgInvalidImage)
+		}
+		return 0, 0, nil
+	}
+
+	if opts.MinWidth != nil && width < *opts.MinWidth {
+		return 0, 0, perror.New(opts.Name, ErrMsgImageTooNarrow)
+	}
+
+	if opts.MaxWidth != nil && width > *opts.MaxWidth {
+		return 0, 0, perror.New(opts.Name, ErrMsgImageTooWide)
+	}
+
+	if opts.MinHeight != nil && height < *opts.MinHeight {
+		return 0, 0, perror.New(opts.Name, ErrMsgImageTooShort)
+	}
+
+	if opts.MaxHeight != nil && height > *opts.MaxHeight {
+		return 0, 0, perror.New(opts.Name, ErrMsgImageTooTall)
+	}
+
+	if opts.AspectRatio != nil &&
+		width*opts.AspectRatio.Height != height*opts.AspectRatio.Width {
+		return 0, 0, perror.New(opts.Name, ErrMsgWrongAspectRatio)
+	}
+
+	return width, height, nil
+}
+
+// ValidateFileSize checks the given file size passes the options set
+func (opts *Options) ValidateFileSize(size int64) error {
+	if opts.MinSize != nil && size < *opts.MinSize {
+		return perror.New(opts.Name, ErrMsgFileTooSmall)
+	}
+
+	if opts.MaxSize != nil && size > *opts.MaxSize {
+		return perror.New(opts.Name, ErrMsgFileTooBig)
+	}
+
+	return nil
+}
+
+// ValidateMime checks the given mime type passes the options set
+func (opts *Options) ValidateMime(mimeType string) error {
+	if len(opts.AuthorizedMimes) == 0 {
+		return nil
+	}
+
+	mimeType = mediaType(mimeType)
+	for _, authorized := range opts.AuthorizedMimes {
+		authorized = mediaType(authorized)
+		if authorized == mimeType {
+			return nil
+		}
+
+		// handle wildcards like image/*
+		if strings.HasSuffix(authorized, "/*") &&
+			strings.HasPrefix(mimeType, strings.TrimSuffix(authorized, "*")) {
+			return nil
+		}
+	}
+	return perror.New(opts.Name, ErrMsgInvalidMime)
+}
+
+// ValidateExtension checks the extension of the given filename passes the
+// options set
+func (opts *Options) ValidateExtension(filename string) error {
+	if len(opts.AuthorizedExtensions) == 0 {
+		return nil
+	}
+
+	ext := normalizeExtension(filepath.Ext(filename))
+	if ext != "" {
+		for _, authorized := range opts.AuthorizedExtensions {
+			if normalizeExtension(authorized) == ext {
+				return nil
+			}
+		}
+	}
+	return perror.New(opts.Name, ErrMsgInvalidExtension)
+}
+
+// ValidateMimeConsistency checks that the extension of the file and the
+// content type sent by the client both match the mime type detected from the
+// content of the file. Nothing is checked if the StrictMime option is not set.
+// An unknown extension, or a missing or generic content type, are not
+// considered as mismatches
+func (opts *Options) ValidateMimeConsistency(header *multipart.FileHeader, mimeType string) error {
+	if !opts.StrictMime {
+		return nil
+	}
+
+	extMime := mime.TypeByExtension(filepath.Ext(header.Filename))
+	if extMime != "" && !mimesMatch(extMime, mimeType) {
+		return perror.New(opts.Name, ErrMsgMimeMismatch)
+	}
+
+	declaredMime := header.Header.Get("Content-Type")
+	if declaredMime != "" &&
+		mediaType(declaredMime) != "application/octet-stream" &&
+		!mimesMatch(declaredMime, mimeType) {
+		return perror.New(opts.Name, ErrMsgMimeMismatch)
+	}
+	return nil
+}
+
+// ValidateChecksum checks the given checksum matches one of the provided
+// digests. The digest to use is picked using the length of the checksum
+func (opts *Options) ValidateChecksum(checksum, sha256Sum, md5Sum string) error {
+	var digest string
+	switch len(checksum) {
+	case hex.EncodedLen(sha256.Size):
+		digest = sha256Sum
+	case hex.EncodedLen(md5.Size):
+		digest = md5Sum
+	default:
+		return perror.New(opts.Name, ErrMsgInvalidChecksum)
+	}
+
+	if !strings.EqualFold(checksum, digest) {
+		return perror.New(opts.Name, ErrMsgChecksumMismatch)
+	}
+	return nil
+}
+
+// mimesMatch checks if the expected mime type matches the one detected
+// from the content of a file
+func mimesMatch(expected, detected string) bool {
+	expected = mediaType(expected)
+	detected = mediaType(detected)
+	if expected == detected {
+		return true
+	}
+	// text files cannot be told apart from their content, so any
+	// textual type is accepted for a plain text content
+	return detected == "text/plain" && strings.HasPrefix(expected, "text/")
+}
+
+// mediaType returns the lowercased media type of a mime type, without its
+// parameters (like "; charset=utf-8")
+func mediaType(mimeType string) string {
+	if i := strings.Index(mimeType, ";"); i != -1 {
+		mimeType = mimeType[:i]
+	}
+	return strings.ToLower(strings.TrimSpace(mimeType))
+}
+
+// normalizeExtension returns the lowercased extension without its leading dot
+func normalizeExtension(ext string) string {
+	return strings.ToLower(strings.TrimPrefix(strings.TrimSpace(ext), "."))
+}
+
 // ApplyTransformations applies all the wanted transformations to the given value
 func (opts *Options) ApplyTransformations(value string) string {
 	if opts.Trim {
@@ -338,3 +864,39 @@ func (opts *Options) ApplyTransformations(value string) string {
 	}
 	return value
 }
+
+// ProcessValue applies the transformations and the default value to the
+// value of a param, and makes sure the result passes the options
+func (opts *Options) ProcessValue(value string, wasProvided bool, defaultValue string) (string, error) {
+	value = opts.ApplyTransformations(value)
+	if value == "" {
+		value = defaultValue
+	}
+
+	sugarIsArrayItem := true
+	if err := opts.Validate(value, wasProvided, !sugarIsArrayItem); err != nil {
+		return "", err
+	}
+	return value, nil
+}
+
+// ProcessValues applies the transformations and the default value to the
+// values of an array param, and makes sure the result passes the options.
+// The provided slice is not modified
+func (opts *Options) ProcessValues(values []string, wasProvided bool, defaultValue string) ([]string, error) {
+	// we make a copy of the original array to keep the original data untouched
+	processed := make([]string, len(values))
+	for i, v := range values {
+		processed[i] = opts.ApplyTransformations(v)
+	}
+
+	// Apply the default value if needed
+	if len(processed) == 0 && defaultValue != "" {
+		processed = strings.Split(defaultValue, ",")
+	}
+
+	if err := opts.ValidateSlice(processed, wasProvided); err != nil {
+		return nil, err
+	}
+	return processed, nil
+}
@@ -5,6 +5,9 @@ package params_test
 import (
 	"errors"
 	"io"
+	"mime/multipart"
+	"net/textproto"
+	"net/url"
 	"os"
 	"path"
 	"reflect"
@@ -148,6 +151,124 @@ func TestNewOptions(t *testing.T) {
 				NoEmptyItems: true,
 			},
 		},
+		{
+			"Set MaxSize", `max_size:"5MB"`,
+			&params.Options{
+				MaxSize: int64Ptr(5 * 1024 * 1024),
+			},
+		},
+		{
+			"Set MinSize", `min_size:"1.5kb"`,
+			&params.Options{
+				MinSize: int64Ptr(1536),
+			},
+		},
+		{
+			"Set MaxSize without unit", `max_size:"42"`,
+			&params.Options{
+				MaxSize: int64Ptr(42),
+			},
+		},
+		{
+			"Set AuthorizedMimes", `mime:"image/*,application/pdf"`,
+			&params.Options{
+				AuthorizedMimes: []string{"image/*", "application/pdf"},
+			},
+		},
+		{
+			"Set image dimensions", `min_width:"1" max_width:"2" min_height:"3" max_height:"4"`,
+			&params.Options{
+				MinWidth:  ptrs.NewInt(1),
+				MaxWidth:  ptrs.NewInt(2),
+				MinHeight: ptrs.NewInt(3),
+				MaxHeight: ptrs.NewInt(4),
+			},
+		},
+		{
+			"Set AspectRatio", `aspect:"16:9"`,
+			&params.Options{
+				AspectRatio: &params.Ratio{Width: 16, Height: 9},
+			},
+		},
+		{
+			"Set AuthorizedExtensions", `ext:"png,jpg"`,
+			&params.Options{
+				AuthorizedExtensions: []string{"png", "jpg"},
+			},
+		},
+		{
+			"Set StrictMime", `params:"strict_mime"`,
+			&params.Options{
+				StrictMime: true,
+			},
+		},
+		{
+			"Set hashes", `hash:"sha256,md5"`,
+			&params.Options{
+				HashSHA256: true,
+				HashMD5:    true,
+			},
+		},
+		{
+			"Set ChecksumField", `checksum_field:"file_sha256"`,
+			&params.Options{
+				ChecksumField: "file_sha256",
+			},
+		},
+		{
+			"Set Inspectors", `inspect:"clamav,policy"`,
+			&params.Options{
+				Inspectors: []string{"clamav", "policy"},
+			},
+		},
+		{
+			"Set ConflictError", `conflict:"error"`,
+			&params.Options{
+				ConflictError: true,
+			},
+		},
+		{
+			"Set default conflict strategy", `conflict:"first"`,
+			&params.Options{},
+		},
+		{
+			"Set Name using param", `param:"my_var" json:"json_var"`,
+			&params.Options{
+				Name: "my_var",
+			},
+		},
+		{
+			"Set Name using json", `json:"my_var,omitempty"`,
+			&params.Options{
+				Name:      "my_var",
+				OmitEmpty: true,
+			},
+		},
+		{
+			"Set Ignore using param", `param:"-" json:"my_var"`,
+			&params.Options{
+				Ignore: true,
+			},
+		},
+		{
+			"param should not be ignored when json is", `param:"my_var,omitempty" json:"-"`,
+			&params.Options{
+				Name:      "my_var",
+				OmitEmpty: true,
+			},
+		},
+		{
+			"Set Aliases", `alias:"perPage,page_size"`,
+			&params.Options{
+				Aliases: []string{"perPage", "page_size"},
+			},
+		},
+		{
+			"Set Deprecated", `deprecated:"use per_page"`,
+			&params.Options{
+				Deprecated: "use per_page",
+			},
+		},
 		{
 			"", `json:"my_var" params:"email,required" maxlen:"30"`,
 			&params.Options{
@@ -195,6 +316,36 @@ func TestNewOptionsError(t *testing.T) {
 		{
 			"Set maxItems nan", `max_items:"nan"`,
 		},
+		{
+			"Set MaxSize nan", `max_size:"nan"`,
+		},
+		{
+			"Set MinSize with unknown unit", `min_size:"5XB"`,
+		},
+		{
+			"Set unsupported hash", `hash:"crc32"`,
+		},
+		{
+			"Set MinWidth nan", `min_width:"nan"`,
+		},
+		{
+			"Set MaxWidth nan", `max_width:"nan"`,
+		},
+		{
+			"Set MinHeight nan", `min_height:"nan"`,
+		},
+		{
+			"Set MaxHeight nan", `max_height:"nan"`,
+		},
+		{
+			"Set AspectRatio without height", `aspect:"16"`,
+		},
+		{
+			"Set AspectRatio with a zero", `aspect:"16:0"`,
+		},
+		{
+			"Set unsupported conflict strategy", `conflict:"last"`,
+		},
 	}
 
 	for _, tc := range testCases {
@@ -517,6 +668,421 @@ func TestValidateSlice(t *testing.T) {
 	}
 }
 
+func TestValidateFileCount(t *testing.T) {
+	testCases := []struct {
+		description   string
+		tag           string
+		count         int
+		expectedError error
+	}{
+		{
+			"no files and not required should work",
+			`json:"field_name"`,
+			0,
+			nil,
+		},
+		{
+			"no files and required should fail",
+			`json:"field_name" params:"required"`,
+			0,
+			perror.New("field_name", params.ErrMsgMissingParameter),
+		},
+		{
+			"max_items with valid data should work",
+			`json:"field_name" max_items:"3"`,
+			3,
+			nil,
+		},
+		{
+			"max_items with invalid data should fail",
+			`json:"field_name" max_items:"1"`,
+			2,
+			perror.New("field_name", params.ErrMsgArrayTooBig),
+		},
+		{
+			"min_items with valid data should work",
+			`json:"field_name" min_items:"2"`,
+			2,
+			nil,
+		},
+		{
+			"min_items with invalid data should fail",
+			`json:"field_name" min_items:"2"`,
+			1,
+			perror.New("field_name", params.ErrMsgArrayTooSmall),
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.description, func(t *testing.T) {
+			t.Parallel()
+			tag := reflect.StructTag(tc.tag)
+			opts, err := params.NewOptions(&tag)
+			require.NoError(t, err, "NewOptions() should not have failed)")
+
+			err = opts.ValidateFileCount(tc.count)
+			if tc.expectedError != nil {
+				assert.Error(t, err, "ValidateFileCount() should have failed")
+				assert.Equal(t, tc.expectedError, err, "ValidateFileCount() returned an unexpected error")
+			} else {
+				assert.NoError(t, err, "ValidateFileCount() should not have failed")
+			}
+		})
+	}
+}
+
+func TestValidateFileSize(t *testing.T) {
+	testCases := []struct {
+		description   string
+		tag           string
+		size          int64
+		expectedError error
+	}{
+		{
+			"no limits should work",
+			`json:"field_name"`,
+			1024,
+			nil,
+		},
+		{
+			"max_size with valid data should work",
+			`json:"field_name" max_size:"1KB"`,
+			1024,
+			nil,
+		},
+		{
+			"max_size with invalid data should fail",
+			`json:"field_name" max_size:"1KB"`,
+			1025,
+			perror.New("field_name", params.ErrMsgFileTooBig),
+		},
+		{
+			"min_size with valid data should work",
+			`json:"field_name" min_size:"10"`,
+			10,
+			nil,
+		},
+		{
+			"min_size with invalid data should fail",
+			`json:"field_name" min_size:"10"`,
+			9,
+			perror.New("field_name", params.ErrMsgFileTooSmall),
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.description, func(t *testing.T) {
+			t.Parallel()
+			tag := reflect.StructTag(tc.tag)
+			opts, err := params.NewOptions(&tag)
+			require.NoError(t, err, "NewOptions() should not have failed)")
+
+			err = opts.ValidateFileSize(tc.size)
+			if tc.expectedError != nil {
+				assert.Error(t, err, "ValidateFileSize() should have failed")
+				assert.Equal(t, tc.expectedError, err, "ValidateFileSize() returned an unexpected error")
+			} else {
+				assert.NoError(t, err, "ValidateFileSize() should not have failed")
+			}
+		})
+	}
+}
+
+func TestValidateMime(t *testing.T) {
+	testCases := []struct {
+		description   string
+		tag           string
+		mime          string
+		expectedError error
+	}{
+		{
+			"no restrictions should work",
+			`json:"field_name"`,
+			"application/pdf",
+			nil,
+		},
+		{
+			"exact match should work",
+			`json:"field_name" mime:"image/png,application/pdf"`,
+			"application/pdf",
+			nil,
+		},
+		{
+			"wildcard match should work",
+			`json:"field_name" mime:"image/*"`,
+			"image/jpeg",
+			nil,
+		},
+		{
+			"parameters should be ignored",
+			`json:"field_name" mime:"text/plain"`,
+			"text/plain; charset=utf-8",
+			nil,
+		},
+		{
+			"no match should fail",
+			`json:"field_name" mime:"image/*,application/pdf"`,
+			"text/plain; charset=utf-8",
+			perror.New("field_name", params.ErrMsgInvalidMime),
+		},
+		{
+			"partial type should not match a wildcard",
+			`json:"field_name" mime:"image/*"`,
+			"imagefoo/png",
+			perror.New("field_name", params.ErrMsgInvalidMime),
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.description, func(t *testing.T) {
+			t.Parallel()
+			tag := reflect.StructTag(tc.tag)
+			opts, err := params.NewOptions(&tag)
+			require.NoError(t, err, "NewOptions() should not have failed)")
+
+			err = opts.ValidateMime(tc.mime)
+			if tc.expectedError != nil {
+				assert.Error(t, err, "ValidateMime() should have failed")
+				assert.Equal(t, tc.expectedError, err, "ValidateMime() returned an unexpected error")
+			} else {
+				assert.NoError(t, err, "ValidateMime() should not have failed")
+			}
+		})
+	}
+}
+
+func TestValidateExtension(t *testing.T) {
+	testCases := []struct {
+		description   string
+		tag           string
+		filename      string
+		expectedError error
+	}{
+		{
+			"no restrictions should work",
+			`json:"field_name"`,
+			"file.exe",
+			nil,
+		},
+		{
+			"valid extension should work",
+			`json:"field_name" ext:"png,jpg"`,
+			"file.jpg",
+			nil,
+		},
+		{
+			"extensions should be case insensitive",
+			`json:"field_name" ext:".PNG"`,
+			"file.png",
+			nil,
+		},
+		{
+			"invalid extension should fail",
+			`json:"field_name" ext:"png,jpg"`,
+			"file.png.exe",
+			perror.New("field_name", params.ErrMsgInvalidExtension),
+		},
+		{
+			"no extension should fail",
+			`json:"field_name" ext:"png,jpg"`,
+			"png",
+			perror.New("field_name", params.ErrMsgInvalidExtension),
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.description, func(t *testing.T) {
+			t.Parallel()
+			tag := reflect.StructTag(tc.tag)
+			opts, err := params.NewOptions(&tag)
+			require.NoError(t, err, "NewOptions() should not have failed)")
+
+			err = opts.ValidateExtension(tc.filename)
+			if tc.expectedError != nil {
+				assert.Error(t, err, "ValidateExtension() should have failed")
+				assert.Equal(t, tc.expectedError, err, "ValidateExtension() returned an unexpected error")
+			} else {
+				assert.NoError(t, err, "ValidateExtension() should not have failed")
+			}
+		})
+	}
+}
+
+func TestValidateMimeConsistency(t *testing.T) {
+	testCases := []struct {
+		description   string
+		tag           string
+		filename      string
+		contentType   string
+		mime          string
+		expectedError error
+	}{
+		{
+			"mismatch without strict_mime should work",
+			`json:"field_name"`,
+			"file.png", "application/pdf",
+			"application/octet-stream",
+			nil,
+		},
+		{
+			"matching extension and content type should work",
+			`json:"field_name" params:"strict_mime"`,
+			"file.jpg", "image/jpeg",
+			"image/jpeg",
+			nil,
+		},
+		{
+			"unknown extension and no content type should work",
+			`json:"field_name" params:"strict_mime"`,
+			"file", "",
+			"image/png",
+			nil,
+		},
+		{
+			"generic content type should work",
+			`json:"field_name" params:"strict_mime"`,
+			"file.png", "application/octet-stream",
+			"image/png",
+			nil,
+		},
+		{
+			"any textual type should work with a text content",
+			`json:"field_name" params:"strict_mime"`,
+			"file.html", "text/html; charset=utf-8",
+			"text/plain; charset=utf-8",
+			nil,
+		},
+		{
+			"mismatching extension should fail",
+			`json:"field_name" params:"strict_mime"`,
+			"file.png", "",
+			"application/pdf",
+			perror.New("field_name", params.ErrMsgMimeMismatch),
+		},
+		{
+			"mismatching content type should fail",
+			`json:"field_name" params:"strict_mime"`,
+			"file", "image/gif",
+			"image/png",
+			perror.New("field_name", params.ErrMsgMimeMismatch),
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.description, func(t *testing.T) {
+			t.Parallel()
+			tag := reflect.StructTag(tc.tag)
+			opts, err := params.NewOptions(&tag)
+			require.NoError(t, err, "NewOptions() should not have failed)")
+
+			header := &multipart.FileHeader{
+				Filename: tc.filename,
+				Header:   textproto.MIMEHeader{},
+			}
+			if tc.contentType != "" {
+				header.Header.Set("Content-Type", tc.contentType)
+			}
+
+			err = opts.ValidateMimeConsistency(header, tc.mime)
+			if tc.expectedError != nil {
+				assert.Error(t, err, "ValidateMimeConsistency() should have failed")
+				assert.Equal(t, tc.expectedError, err, "ValidateMimeConsistency() returned an unexpected error")
+			} else {
+				assert.NoError(t, err, "ValidateMimeConsistency() should not have failed")
+			}
+		})
+	}
+}
+
+func TestLookupKey(t *testing.T) {
+	testCases := []struct {
+		description   string
+		aliases       []string
+		source        url.Values
+		expectedKey   string
+		expectedFound bool
+	}{
+		{
+			"Name should be found",
+			[]string{"perPage"},
+			url.Values{"per_page": []string{"10"}},
+			"per_page", true,
+		},
+		{
+			"Alias should be found",
+			[]string{"perPage", "page_size"},
+			url.Values{"page_size": []string{"10"}},
+			"page_size", true,
+		},
+		{
+			"Name should have precedence",
+			[]string{"perPage"},
+			url.Values{"perPage": []string{"10"}, "per_page": []string{"20"}},
+			"per_page", true,
+		},
+		{
+			"Name should be returned when nothing found",
+			[]string{"perPage"},
+			url.Values{},
+			"per_page", false,
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.description, func(t *testing.T) {
+			t.Parallel()
+			opts := &params.Options{Name: "per_page", Aliases: tc.aliases}
+			key, found := opts.LookupKey(tc.source)
+			assert.Equal(t, tc.expectedKey, key, "LookupKey() returned the wrong key")
+			assert.Equal(t, tc.expectedFound, found, "LookupKey() returned the wrong found value")
+		})
+	}
+}
+
+func TestIsDeprecatedKey(t *testing.T) {
+	testCases := []struct {
+		description string
+		opts        *params.Options
+		key         string
+		expected    bool
+	}{
+		{
+			"Not deprecated",
+			&params.Options{Name: "per_page", Aliases: []string{"perPage"}},
+			"perPage", false,
+		},
+		{
+			"Deprecated field without aliases",
+			&params.Options{Name: "per_page", Deprecated: "will be removed"},
+			"per_page", true,
+		},
+		{
+			"Deprecated alias",
+			&params.Options{Name: "per_page", Aliases: []string{"perPage"}, Deprecated: "use per_page"},
+			"perPage", true,
+		},
+		{
+			"Name of a field with deprecated aliases",
+			&params.Options{Name: "per_page", Aliases: []string{"perPage"}, Deprecated: "use per_page"},
+			"per_page", false,
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.description, func(t *testing.T) {
+			t.Parallel()
+			assert.Equal(t, tc.expected, tc.opts.IsDeprecatedKey(tc.key), "IsDeprecatedKey() returned the wrong value")
+		})
+	}
+}
+
 func TestApplyTransformations(t *testing.T) {
 	testCases := []struct {
 		description string
@@ -657,3 +1223,7 @@ func TestValidateFileContentMock(t *testing.T) {
 		assert.Equal(t, exectedError, err, "ValidateFileContent() did not fail with the expected error")
 	})
 }
+
+func int64Ptr(v int64) *int64 {
+	return &v
+}
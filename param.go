@@ -9,8 +9,10 @@ import (
 	"reflect"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/Nivl/go-params/perror"
+	"github.com/Nivl/go-types/slices"
 
 	"github.com/Nivl/go-params/formfile"
 )
@@ -20,6 +22,21 @@ type Param struct {
 	Value *reflect.Value
 	Info  *reflect.StructField
 	Tags  *reflect.StructTag
+
+	// FileInspectors contains the inspectors to run on every file
+	FileInspectors []FileInspector
+
+	// NamedFileInspectors contains the inspectors that can be requested
+	// by a file using the inspect tag
+	NamedFileInspectors map[string]FileInspector
+
+	// NamingStrategy is used to generate the name of the param when no
+	// names are set in the tags. The Go field name is used if nil
+	NamingStrategy NamingStrategy
+
+	// CaseInsensitive means the keys of the source are matched without
+	// taking care of their case
+	CaseInsensitive bool
 }
 
 var userUploadErrors = map[error]bool{
@@ -28,11 +45,18 @@ var userUploadErrors = map[error]bool{
 	multipart.ErrMessageTooLarge: true,
 }
 
-// SetFile sets the value of the param using the provided source to find the file
+// SetFile sets the value of the param using the provided source to find the file.
+// A []*formfile.FormFile param requires the source to be a
+// formfile.MultiFileHolder
 func (p *Param) SetFile(source formfile.FileHolder) error {
 	// We make sure we are using the right structure to store the file
-	if p.Info.Type.String() != "*formfile.FormFile" {
-		return fmt.Errorf("the only accepted type for a file is *formfile.FormFile, got %s", p.Info.Type)
+	isSlice := false
+	switch p.Info.Type.String() {
+	case "*formfile.FormFile":
+	case "[]*formfile.FormFile":
+		isSlice = true
+	default:
+		return fmt.Errorf("the only accepted type for a file is *formfile.FormFile or []*formfile.FormFile, got %s", p.Info.Type)
 	}
 
 	// We parse the tag to get the options
@@ -47,47 +71,269 @@ func (p *Param) SetFile(source formfile.FileHolder) error {
 	}
 
 	if opts.Name == "" {
-		opts.Name = p.Info.Name
+		opts.Name = p.defaultName()
+	}
+
+	if isSlice {
+		if opts.ChecksumField != "" {
+			return fmt.Errorf("field %s: checksum_field cannot be used on multiple files", p.Info.Name)
+		}
+		formFiles, err := p.formFiles(source, opts)
+		if err != nil {
+			return err
+		}
+		// if there's no files and it's ok, then we're done
+		if len(formFiles) > 0 {
+			p.Value.Set(reflect.ValueOf(formFiles))
+		}
+		return nil
 	}
 
+	ff, err := p.formFile(source, opts)
+	if err != nil {
+		return err
+	}
+	if ff != nil {
+		p.Value.Set(reflect.ValueOf(ff))
+	}
+	return nil
+}
+
+// formFile returns the file of the param using the provided source to find
+// it. No errors are returned if the file is missing and not required
+func (p *Param) formFile(source formfile.FileHolder, opts *Options) (*formfile.FormFile, error) {
 	file, header, err := source.FormFile(opts.Name)
 	if err != nil {
 		// if the file is missing it's ok as long as it's not required
 		if err == http.ErrMissingFile {
 			if opts.Required {
-				return perror.New(opts.Name, ErrMsgMissingParameter)
+				return nil, perror.New(opts.Name, ErrMsgMissingParameter)
 			}
 			// if there's no file and it's not required, then we're done
-			return nil
+			return nil, nil
 		}
 		// check if it failed because of a malformed request, etc.
 		if _, isUserError := userUploadErrors[err]; isUserError {
-			return perror.New(opts.Name, err.Error())
+			return nil, perror.New(opts.Name, err.Error())
 		}
 		// system error
+		return nil, err
+	}
+
+	return p.newFormFile(opts, file, header)
+}
+
+// VerifyChecksum checks the file of the param matches the provided checksum.
+// Nothing is checked if there's no file or no checksum
+func (p *Param) VerifyChecksum(checksum string) error {
+	opts, err := NewOptions(p.Tags)
+	if err != nil {
 		return err
 	}
 
-	ff := &formfile.FormFile{
+	if opts.Name == "" {
+		opts.Name = p.defaultName()
+	}
+
+	ff, ok := p.Value.Interface().(*formfile.FormFile)
+	if !ok {
+		return fmt.Errorf("field %s: a checksum can only be verified on a *formfile.FormFile, got %s", p.Info.Name, p.Info.Type)
+	}
+	if ff == nil || checksum == "" {
+		return nil
+	}
+
+	return opts.ValidateChecksum(checksum, ff.SHA256, ff.MD5)
+}
+
+// formFiles returns the files of a []*formfile.FormFile param using the
+// provided source to find them
+func (p *Param) formFiles(source formfile.FileHolder, opts *Options) ([]*formfile.FormFile, error) {
+	multiSource, ok := source.(formfile.MultiFileHolder)
+	if !ok {
+		return nil, fmt.Errorf("field %s expects multiple files but %T is not a formfile.MultiFileHolder", p.Info.Name, source)
+	}
+
+	files, headers, err := multiSource.FormFiles(opts.Name)
+	// a missing file is not an error yet, it will be treated as an
+	// empty list
+	if err != nil && err != http.ErrMissingFile {
+		// check if it failed because of a malformed request, etc.
+		if _, isUserError := userUploadErrors[err]; isUserError {
+			return nil, perror.New(opts.Name, err.Error())
+		}
+		// system error
+		return nil, err
+	}
+
+	if len(files) != len(headers) {
+		closeFiles(files)
+		return nil, fmt.Errorf("field %s: got %d files for %d headers", p.Info.Name, len(files), len(headers))
+	}
+
+	if err := opts.ValidateFileCount(len(files)); err != nil {
+		closeFiles(files)
+		return nil, err
+	}
+
+	formFiles := make([]*formfile.FormFile, len(files))
+	for i := range files {
+		formFiles[i], err = p.newFormFile(opts, files[i], headers[i])
+		if err != nil {
+			// newFormFile already closed the invalid file, but we don't
+			// want to leak the other ones
+			closeFiles(files[:i])
+			closeFiles(files[i+1:])
+			return nil, err
+		}
+	}
+	return formFiles, nil
+}
+
+// closeFiles closes all the provided files. The errors are ignored since
+// the files are being discarded
+func closeFiles(files []multipart.File) {
+	for _, f := range files {
+		if f != nil {
+			_ = f.Close()
+		}
+	}
+}
+
+// newFormFile creates a new FormFile from the provided file and makes sure
+// its content passes the options set and the inspectors
+func (p *Param) newFormFile(opts *Options, file multipart.File, header *multipart.FileHeader) (ff *formfile.FormFile, err error) {
+	// The file is not returned if it's invalid, so nobody else will
+	// be able to close it
+	defer func() {
+		if err != nil {
+			_ = file.Close()
+		}
+	}()
+
+	ff = &formfile.FormFile{
 		File:   file,
 		Header: header,
 	}
 
-	ff.Mime, err = opts.ValidateFileContent(ff.File)
+	// The digests are computed while the content is being validated, to
+	// avoid reading the whole file one more time
+	digests, err := opts.newDigestReader(file)
+	if err != nil {
+		return nil, err
+	}
+
+	ff.Mime, err = opts.ValidateFileContent(digests)
 	if err != nil {
 		if err == io.EOF {
 			if header.Size == 0 {
-				return perror.New(opts.Name, ErrMsgEmptyFile)
+				return nil, perror.New(opts.Name, ErrMsgEmptyFile)
 			}
-			return perror.New(opts.Name, ErrMsgCorruptedFile)
+			return nil, perror.New(opts.Name, ErrMsgCorruptedFile)
 		}
-		return err
+		return nil, err
+	}
+
+	if err := opts.ValidateFileSize(header.Size); err != nil {
+		return nil, err
+	}
+
+	if err := opts.ValidateMime(ff.Mime); err != nil {
+		return nil, err
+	}
+
+	if err := opts.ValidateExtension(header.Filename); err != nil {
+		return nil, err
 	}
 
-	p.Value.Set(reflect.ValueOf(ff))
+	if err := opts.ValidateMimeConsistency(header, ff.Mime); err != nil {
+		return nil, err
+	}
+
+	ff.Width, ff.Height, err = opts.ValidateImageDimensions(digests, ff.Mime)
+	if err != nil {
+		return nil, err
+	}
+
+	ff.SHA256, ff.MD5, err = digests.Sums()
+	if err != nil {
+		return nil, err
+	}
+
+	if err := p.inspectFile(opts, ff); err != nil {
+		return nil, err
+	}
+	return ff, nil
+}
+
+// inspectFile runs all the inspectors that apply to the file
+func (p *Param) inspectFile(opts *Options, ff *formfile.FormFile) error {
+	inspectors := make([]FileInspector, 0, len(p.FileInspectors)+len(opts.Inspectors))
+	inspectors = append(inspectors, p.FileInspectors...)
+	for _, name := range opts.Inspectors {
+		inspector, found := p.NamedFileInspectors[name]
+		if !found {
+			return fmt.Errorf("file inspector %s for field %s does not exist", name, p.Info.Name)
+		}
+		inspectors = append(inspectors, inspector)
+	}
+
+	for _, inspector := range inspectors {
+		err := inspector.InspectFile(opts.Name, ff)
+		// We rewind the file for the next inspector, or for the
+		// user of the struct
+		if _, seekErr := ff.File.Seek(0, io.SeekStart); err == nil {
+			err = seekErr
+		}
+		if err != nil {
+			return err
+		}
+	}
 	return nil
 }
 
+// defaultName returns the name of the param to use when no names
+// are set in the tags
+func (p *Param) defaultName() string {
+	if p.NamingStrategy != nil {
+		return p.NamingStrategy(p.Info.Name)
+	}
+	return p.Info.Name
+}
+
+// matchingKeys returns all the keys of the source that can be used to set
+// the param, by order of precedence. The exact matches always come first
+func (p *Param) matchingKeys(opts *Options, source url.Values) []string {
+	keys := []string{}
+	for _, k := range opts.Keys() {
+		if _, found := source[k]; found {
+			keys = append(keys, k)
+		}
+	}
+
+	if p.CaseInsensitive {
+		sourceKeys := sortedKeys(source)
+		for _, k := range opts.Keys() {
+			for _, sourceKey := range sourceKeys {
+				if strings.EqualFold(k, sourceKey) && !slices.HasString(sourceKey, keys) {
+					keys = append(keys, sourceKey)
+				}
+			}
+		}
+	}
+	return keys
+}
+
+// lookupKey returns the key of the source to use to set the param.
+// The name of the param is returned if the source doesn't contain the param
+func (p *Param) lookupKey(opts *Options, source url.Values) (key string, found bool) {
+	keys := p.matchingKeys(opts, source)
+	if len(keys) == 0 {
+		return opts.Name, false
+	}
+	return keys[0], true
+}
+
 // SetValue sets the value of the param using the provided source
 func (p *Param) SetValue(source url.Values) error {
 	// We parse the tag to get the options
@@ -103,7 +349,7 @@ func (p *Param) SetValue(source url.Values) error {
 	}
 
 	if opts.Name == "" {
-		opts.Name = p.Info.Name
+		opts.Name = p.defaultName()
 	}
 
 	// if we have a slice we need to treat it differently
@@ -111,14 +357,9 @@ func (p *Param) SetValue(source url.Values) error {
 		return p.setSliceValue(source, opts, defaultValue)
 	}
 
-	value := opts.ApplyTransformations(source.Get(opts.Name))
-	if value == "" {
-		value = defaultValue
-	}
-
-	_, valueProvided := source[opts.Name]
-	sugarIsArrayItem := true
-	if err := opts.Validate(value, valueProvided, !sugarIsArrayItem); err != nil {
+	key, valueProvided := p.lookupKey(opts, source)
+	value, err := opts.ProcessValue(source.Get(key), valueProvided, defaultValue)
+	if err != nil {
 		return err
 	}
 
@@ -133,23 +374,35 @@ func (p *Param) SetValue(source url.Values) error {
 		field := reflect.Indirect(*p.Value)
 		switch field.Kind() {
 		case reflect.Bool:
-			v, err := strconv.ParseBool(value)
+			v, err := boolValue(opts, value)
 			if err != nil {
-				return perror.New(opts.Name, ErrMsgInvalidBoolean)
+				return err
 			}
 			field.SetBool(v)
 		case reflect.String:
 			field.SetString(value)
 		case reflect.Int:
-			v, err := strconv.ParseInt(value, 10, 64)
+			v, err := intValue(opts, value)
 			if err != nil {
-				return perror.New(opts.Name, ErrMsgInvalidInteger)
+				return err
 			}
-			field.SetInt(v)
+			field.SetInt(int64(v))
 		case reflect.Struct:
-			if scanner, ok := p.Value.Interface().(Scanner); ok {
-				if err := scanner.ScanString(value); err != nil {
-					return perror.New(opts.Name, err.Error())
+			// time.Time is not a Scanner but is supported using RFC 3339
+			if field.Type() == timeType {
+				v, err := timeValue(opts, value)
+				if err != nil {
+					return err
+				}
+				field.Set(reflect.ValueOf(v))
+				break
+			}
+
+			// We use the address of the struct to make sure the pointer
+			// receivers are available
+			if scanner, ok := field.Addr().Interface().(Scanner); ok {
+				if err := scanValue(opts, scanner, value); err != nil {
+					return err
 				}
 			}
 		}
@@ -159,25 +412,9 @@ func (p *Param) SetValue(source url.Values) error {
 
 // setSliceValue sets the values of the slice param using the provided source
 func (p *Param) setSliceValue(source url.Values, opts *Options, defaultValue string) error {
-	originalValues, valueProvided := source[opts.Name]
-	values := []string{}
-
-	// we make a copy of the original array to keep the original data untouched
-	if valueProvided {
-		values = make([]string, len(originalValues))
-		copy(values, originalValues)
-	}
-
-	for i, v := range values {
-		values[i] = opts.ApplyTransformations(v)
-	}
-
-	// Apply the default value if needed
-	if len(values) == 0 && defaultValue != "" {
-		values = strings.Split(defaultValue, ",")
-	}
-
-	if err := opts.ValidateSlice(values, valueProvided); err != nil {
+	key, valueProvided := p.lookupKey(opts, source)
+	values, err := opts.ProcessValues(source[key], valueProvided, defaultValue)
+	if err != nil {
 		return err
 	}
 
@@ -192,56 +429,62 @@ func (p *Param) setSliceValue(source url.Values, opts *Options, defaultValue str
 			isPointer = true
 		}
 
+		// setItem stores a value in the slice, once converted to the type
+		// of the items (named types, pointers)
+		setItem := func(slice reflect.Value, i int, v reflect.Value) {
+			v = v.Convert(sliceStructType)
+			if isPointer {
+				ptr := reflect.New(sliceStructType)
+				ptr.Elem().Set(v)
+				v = ptr
+			}
+			slice.Index(i).Set(v)
+		}
+
 		// for each type we need to loop over the array of values, cast them
 		// to the right type, and assign them to the param
 		switch sliceStructType.Kind() {
 		case reflect.String:
-			var finalValue reflect.Value
-			if isPointer {
-				finalValue = reflect.MakeSlice(sliceType, len(values), cap(values))
-				for i, value := range values {
-					v := value
-					finalValue.Index(i).Set(reflect.ValueOf(&v))
-				}
-			} else {
-				finalValue = reflect.ValueOf(values)
+			finalValues := reflect.MakeSlice(sliceType, len(values), cap(values))
+			for i, value := range values {
+				setItem(finalValues, i, reflect.ValueOf(value))
 			}
-			p.Value.Set(finalValue)
+			p.Value.Set(finalValues)
 		case reflect.Bool:
 			finalValues := reflect.MakeSlice(sliceType, len(values), cap(values))
 			for i, value := range values {
-				boolVal, err := strconv.ParseBool(value)
+				boolVal, err := boolValue(opts, value)
 				if err != nil {
-					return perror.New(opts.Name, ErrMsgInvalidBoolean)
+					return err
 				}
-				var v reflect.Value
-				if isPointer {
-					v = reflect.ValueOf(&boolVal)
-				} else {
-					v = reflect.ValueOf(boolVal)
-				}
-				finalValues.Index(i).Set(v)
+				setItem(finalValues, i, reflect.ValueOf(boolVal))
 			}
 			p.Value.Set(finalValues)
-
 		case reflect.Int:
 			finalValues := reflect.MakeSlice(sliceType, len(values), cap(values))
 			for i, value := range values {
-				int64Val, err := strconv.ParseInt(value, 10, 64)
+				intVal, err := intValue(opts, value)
 				if err != nil {
-					return perror.New(opts.Name, ErrMsgInvalidInteger)
+					return err
 				}
-				intVal := int(int64Val)
-				var v reflect.Value
-				if isPointer {
-					v = reflect.ValueOf(&intVal)
-				} else {
-					v = reflect.ValueOf(intVal)
-				}
-				finalValues.Index(i).Set(v)
+				setItem(finalValues, i, reflect.ValueOf(intVal))
 			}
 			p.Value.Set(finalValues)
 		case reflect.Struct:
+			// time.Time is not a Scanner but is supported using RFC 3339
+			if sliceStructType == timeType {
+				finalValues := reflect.MakeSlice(sliceType, len(values), cap(values))
+				for i, value := range values {
+					timeVal, err := timeValue(opts, value)
+					if err != nil {
+						return err
+					}
+					setItem(finalValues, i, reflect.ValueOf(timeVal))
+				}
+				p.Value.Set(finalValues)
+				break
+			}
+
 			if _, ok := reflect.New(sliceStructType).Interface().(Scanner); ok {
 				slice := reflect.MakeSlice(sliceType, len(values), cap(values))
 
@@ -249,8 +492,8 @@ func (p *Param) setSliceValue(source url.Values, opts *Options, defaultValue str
 					// We need to create a pointer to be able to cast to Scanner
 					strct := reflect.New(sliceStructType)
 					if scanner, ok := strct.Interface().(Scanner); ok {
-						if err := scanner.ScanString(value); err != nil {
-							return perror.New(opts.Name, err.Error())
+						if err := scanValue(opts, scanner, value); err != nil {
+							return err
 						}
 						if !isPointer {
 							// Because we want an array of struct, we use Indirect to deference
@@ -266,3 +509,39 @@ func (p *Param) setSliceValue(source url.Values, opts *Options, defaultValue str
 	}
 	return nil
 }
+
+// boolValue converts the value of a param to a boolean
+func boolValue(opts *Options, value string) (bool, error) {
+	v, err := strconv.ParseBool(value)
+	if err != nil {
+		return false, perror.New(opts.Name, ErrMsgInvalidBoolean)
+	}
+	return v, nil
+}
+
+// intValue converts the value of a param to an integer
+func intValue(opts *Options, value string) (int, error) {
+	v, err := strconv.ParseInt(value, 10, 64)
+	if err != nil {
+		return 0, perror.New(opts.Name, ErrMsgInvalidInteger)
+	}
+	return int(v), nil
+}
+
+// timeValue converts the value of a param to a time.Time. The value needs
+// to use the RFC 3339 format, and the time is normalized to UTC
+func timeValue(opts *Options, value string) (time.Time, error) {
+	v, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		return time.Time{}, perror.New(opts.Name, ErrMsgInvalidTime)
+	}
+	return v.UTC(), nil
+}
+
+// scanValue fills a Scanner using the value of a param
+func scanValue(opts *Options, scanner Scanner, value string) error {
+	if err := scanner.ScanString(value); err != nil {
+		return perror.New(opts.Name, err.Error())
+	}
+	return nil
+}
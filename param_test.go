@@ -3,10 +3,13 @@
 package params_test
 
 import (
+	"errors"
+	"io"
 	"mime/multipart"
 	"net/http"
 	"net/url"
 	"os"
+	"path"
 	"reflect"
 	"testing"
 
@@ -49,6 +52,8 @@ func TestSetValue(t *testing.T) {
 		t.Run("slices of pointers", subTestsSetValueBoolSlicePointer)
 	})
 
+	t.Run("key matching", subTestSetValueKeyMatching)
+
 	t.Run("scannable struct", func(t *testing.T) {
 		t.Parallel()
 		t.Run("regular", subTestSetValueScannableStruct)
@@ -66,6 +71,498 @@ func TestSetFile(t *testing.T) {
 	t.Run("wrong struct", subTestSetFileWrongStruct)
 	t.Run("formFile returned an unknown error", subTestSetFileFormFileFail)
 	t.Run("invalid struct", subTestSetFileInvalidStruct)
+	t.Run("multiple files", subTestSetFileMultiple)
+	t.Run("multiple files closed on error", subTestSetFileMultipleClosedOnError)
+	t.Run("digests computed while validating", subTestSetFileDigests)
+	t.Run("requested digests", subTestSetFileRequestedDigests)
+	t.Run("size, mime and dimensions restrictions", subTestSetFileRestrictions)
+	t.Run("multiple files with a single file holder", subTestSetFileMultipleSingleHolder)
+	t.Run("inspectors", subTestSetFileInspectors)
+}
+
+func subTestSetFileMultiple(t *testing.T) {
+	t.Parallel()
+
+	type strct struct {
+		Files []*formfile.FormFile `from:"file" json:"files" params:"image" min_items:"1" max_items:"2"`
+	}
+
+	testCases := []struct {
+		description   string
+		filenames     []string
+		expectedError error
+	}{
+		{
+			"Valid images should work",
+			[]string{"black_pixel.png", "black_pixel.png"},
+			nil,
+		},
+		{
+			"No files should fail",
+			[]string{},
+			perror.New("files", params.ErrMsgArrayTooSmall),
+		},
+		{
+			"Too many files should fail",
+			[]string{"black_pixel.png", "black_pixel.png", "black_pixel.png"},
+			perror.New("files", params.ErrMsgArrayTooBig),
+		},
+		{
+			"An invalid image should fail",
+			[]string{"black_pixel.png", "invalid_content.png"},
+			perror.New("files", params.ErrMsgInvalidImage),
+		},
+		{
+			"An empty file should fail",
+			[]string{"empty"},
+			perror.New("files", params.ErrMsgEmptyFile),
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.description, func(t *testing.T) {
+			t.Parallel()
+
+			// init the mocks
+			mockCtrl := gomock.NewController(t)
+			defer mockCtrl.Finish()
+
+			// Expectations
+			fileHolder := mockformfile.NewMockMultiFileHolder(mockCtrl)
+			onFormFiles := fileHolder.EXPECT().FormFiles("files")
+
+			if len(tc.filenames) == 0 {
+				onFormFiles.Return(nil, nil, http.ErrMissingFile)
+			} else {
+				cwd, _ := os.Getwd()
+				files := make([]multipart.File, len(tc.filenames))
+				headers := make([]*multipart.FileHeader, len(tc.filenames))
+				for i, filename := range tc.filenames {
+					header, file := testformfile.NewMultipartData(t, cwd, filename)
+					defer file.Close()
+					files[i] = file
+					headers[i] = header
+				}
+				onFormFiles.Return(files, headers, nil)
+			}
+
+			s := strct{}
+			paramList := reflect.ValueOf(&s).Elem()
+			p := newParamFromStructValue(&paramList, 0)
+			err := p.SetFile(fileHolder)
+
+			if tc.expectedError != nil {
+				require.Error(t, err, "Expected SetFile to return an error")
+				assert.Equal(t, tc.expectedError, err, "Wrong error returned")
+			} else {
+				require.NoError(t, err, "Expected SetFile not to return an error")
+				require.Len(t, s.Files, len(tc.filenames), "Wrong number of files")
+				for i, f := range s.Files {
+					assert.Equal(t, tc.filenames[i], f.Header.Filename, "Wrong file")
+					assert.Equal(t, "image/png", f.Mime, "Wrong mime type")
+				}
+			}
+		})
+	}
+}
+
+// trackedFile is a multipart.File that records whether it has been
+// closed, and the number of bytes read
+type trackedFile struct {
+	*os.File
+	closed bool
+	read   int
+}
+
+func (f *trackedFile) Read(p []byte) (int, error) {
+	n, err := f.File.Read(p)
+	f.read += n
+	return n, err
+}
+
+func (f *trackedFile) Close() error {
+	f.closed = true
+	return f.File.Close()
+}
+
+func subTestSetFileMultipleClosedOnError(t *testing.T) {
+	t.Parallel()
+
+	type strct struct {
+		Files []*formfile.FormFile `from:"file" json:"files" params:"image"`
+	}
+
+	// init the mocks
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	// Expectations
+	cwd, _ := os.Getwd()
+	filenames := []string{"black_pixel.png", "invalid_content.png", "black_pixel.png"}
+	tracked := make([]*trackedFile, len(filenames))
+	files := make([]multipart.File, len(filenames))
+	headers := make([]*multipart.FileHeader, len(filenames))
+	for i, filename := range filenames {
+		header, file := testformfile.NewMultipartData(t, cwd, filename)
+		tracked[i] = &trackedFile{File: file}
+		files[i] = tracked[i]
+		headers[i] = header
+	}
+	fileHolder := mockformfile.NewMockMultiFileHolder(mockCtrl)
+	fileHolder.EXPECT().FormFiles("files").Return(files, headers, nil)
+
+	s := strct{}
+	paramList := reflect.ValueOf(&s).Elem()
+	p := newParamFromStructValue(&paramList, 0)
+	err := p.SetFile(fileHolder)
+	require.Error(t, err, "Expected SetFile to return an error")
+	assert.Equal(t, perror.New("files", params.ErrMsgInvalidImage), err, "Wrong error returned")
+	assert.Nil(t, s.Files, "Expected no files to be set")
+	for i, f := range tracked {
+		assert.True(t, f.closed, "Expected file %d to be closed", i)
+	}
+}
+
+func subTestSetFileDigests(t *testing.T) {
+	t.Parallel()
+
+	type strct struct {
+		File *formfile.FormFile `from:"file" json:"file" hash:"sha256,md5"`
+	}
+
+	// init the mocks
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	// Expectations
+	cwd, _ := os.Getwd()
+	header, file := testformfile.NewMultipartData(t, cwd, "LICENSE")
+	tracked := &trackedFile{File: file}
+	defer tracked.Close()
+	fileHolder := mockformfile.NewMockFileHolder(mockCtrl)
+	fileHolder.EXPECT().FormFile("file").Return(tracked, header, nil)
+
+	s := strct{}
+	paramList := reflect.ValueOf(&s).Elem()
+	p := newParamFromStructValue(&paramList, 0)
+	err := p.SetFile(fileHolder)
+	require.NoError(t, err, "Expected SetFile not to return an error")
+
+	expectedSHA256, err := filetype.SHA256Sum(file)
+	require.NoError(t, err, "SHA256Sum() should have worked")
+	assert.Equal(t, expectedSHA256, s.File.SHA256, "Wrong SHA-256")
+	assert.Len(t, s.File.MD5, 32, "Expected the MD5 to be set")
+	assert.Equal(t, int(header.Size), tracked.read, "The file should have been read only once")
+}
+
+func subTestSetFileRequestedDigests(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		description    string
+		tag            string
+		expectedSHA256 string
+		expectedMD5    string
+	}{
+		{
+			"nothing requested",
+			`from:"file" json:"file"`,
+			"", "",
+		},
+		{
+			"sha256 only",
+			`from:"file" json:"file" hash:"sha256"`,
+			"5c91de0e65daa63c59a8584de5dbb01d9fdd5c0461cfd2af96f56e00a116b709", "",
+		},
+		{
+			"md5 only",
+			`from:"file" json:"file" hash:"md5"`,
+			"", "43f7f1c29e856bf18476db5f74b261dc",
+		},
+		{
+			"checksum_field computes everything",
+			`from:"file" json:"file" checksum_field:"checksum"`,
+			"5c91de0e65daa63c59a8584de5dbb01d9fdd5c0461cfd2af96f56e00a116b709", "43f7f1c29e856bf18476db5f74b261dc",
+		},
+	}
+
+	content, err := os.ReadFile(path.Join("testdata", "black_pixel.png"))
+	require.NoError(t, err, "ReadFile() should not have failed")
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.description, func(t *testing.T) {
+			t.Parallel()
+
+			var ff *formfile.FormFile
+			value := reflect.ValueOf(&ff).Elem()
+			info := &reflect.StructField{Name: "File", Type: value.Type(), Tag: reflect.StructTag(tc.tag)}
+			p := &params.Param{Value: &value, Info: info, Tags: &info.Tag}
+
+			err := p.SetFile(formfile.NewMemoryHolder(map[string][]byte{"file": content}))
+			require.NoError(t, err, "SetFile() should not have failed")
+			require.NotNil(t, ff, "the file should have been set")
+			assert.Equal(t, tc.expectedSHA256, ff.SHA256, "unexpected SHA-256")
+			assert.Equal(t, tc.expectedMD5, ff.MD5, "unexpected MD5")
+
+			pos, err := ff.File.Seek(0, io.SeekCurrent)
+			require.NoError(t, err, "Seek() should not have failed")
+			assert.Equal(t, int64(0), pos, "the file should have been rewound")
+		})
+	}
+}
+
+func subTestSetFileRestrictions(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		description   string
+		tag           string
+		filename      string
+		expectedError error
+	}{
+		{
+			"valid mime and size should work",
+			`from:"file" json:"file" mime:"image/*" max_size:"1KB"`,
+			"black_pixel.png",
+			nil,
+		},
+		{
+			"file too big should fail",
+			`from:"file" json:"file" max_size:"10B"`,
+			"black_pixel.png",
+			perror.New("file", params.ErrMsgFileTooBig),
+		},
+		{
+			"file too small should fail",
+			`from:"file" json:"file" min_size:"1MB"`,
+			"black_pixel.png",
+			perror.New("file", params.ErrMsgFileTooSmall),
+		},
+		{
+			"image too small should fail",
+			`from:"file" json:"file" min_width:"256"`,
+			"black_pixel.png",
+			perror.New("file", params.ErrMsgImageTooNarrow),
+		},
+		{
+			"wrong extension should fail",
+			`from:"file" json:"file" ext:"jpg,jpeg"`,
+			"black_pixel.png",
+			perror.New("file", params.ErrMsgInvalidExtension),
+		},
+		{
+			"content not matching the extension should fail",
+			`from:"file" json:"file" params:"strict_mime"`,
+			"invalid_magic.png",
+			perror.New("file", params.ErrMsgMimeMismatch),
+		},
+		{
+			"content matching the extension should work",
+			`from:"file" json:"file" params:"strict_mime" ext:"png"`,
+			"black_pixel.png",
+			nil,
+		},
+		{
+			"wrong mime should fail",
+			`from:"file" json:"file" mime:"application/pdf"`,
+			"LICENSE",
+			perror.New("file", params.ErrMsgInvalidMime),
+		},
+		{
+			"small text file with a text mime should work",
+			`from:"file" json:"file" mime:"text/plain"`,
+			"note.txt",
+			nil,
+		},
+		{
+			"small text file with strict_mime should work",
+			`from:"file" json:"file" params:"strict_mime"`,
+			"note.txt",
+			nil,
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.description, func(t *testing.T) {
+			t.Parallel()
+
+			// init the mocks
+			mockCtrl := gomock.NewController(t)
+			defer mockCtrl.Finish()
+
+			// Expectations
+			cwd, _ := os.Getwd()
+			fileHeader, fileData := testformfile.NewMultipartData(t, cwd, tc.filename)
+			defer fileData.Close()
+			fileHolder := mockformfile.NewMockFileHolder(mockCtrl)
+			fileHolder.EXPECT().FormFile("file").Return(fileData, fileHeader, nil)
+
+			// Call the function to test
+			s := struct {
+				File *formfile.FormFile
+			}{}
+			paramList := reflect.ValueOf(&s).Elem()
+			p := newParamFromStructValue(&paramList, 0)
+			tag := reflect.StructTag(tc.tag)
+			p.Tags = &tag
+
+			err := p.SetFile(fileHolder)
+
+			if tc.expectedError != nil {
+				require.Error(t, err, "Expected SetFile to return an error")
+				assert.Equal(t, tc.expectedError, err, "Wrong error returned")
+			} else {
+				require.NoError(t, err, "Expected SetFile not to return an error")
+				assert.NotNil(t, s.File, "Expected File NOT to be nil")
+			}
+		})
+	}
+}
+
+func subTestSetFileInspectors(t *testing.T) {
+	t.Parallel()
+
+	accept := params.FileInspectorFunc(func(field string, file *formfile.FormFile) error {
+		return nil
+	})
+	reject := params.FileInspectorFunc(func(field string, file *formfile.FormFile) error {
+		return perror.New(field, "infected")
+	})
+	systemErr := errors.New("scanner unreachable")
+	fail := params.FileInspectorFunc(func(field string, file *formfile.FormFile) error {
+		return systemErr
+	})
+
+	testCases := []struct {
+		description   string
+		tag           string
+		global        []params.FileInspector
+		named         map[string]params.FileInspector
+		expectedError error
+	}{
+		{
+			"accepting inspectors should work",
+			`json:"file" inspect:"clamav"`,
+			[]params.FileInspector{accept},
+			map[string]params.FileInspector{"clamav": accept},
+			nil,
+		},
+		{
+			"rejecting global inspector should fail",
+			`json:"file"`,
+			[]params.FileInspector{accept, reject},
+			nil,
+			perror.New("file", "infected"),
+		},
+		{
+			"rejecting named inspector should fail",
+			`json:"file" inspect:"clamav"`,
+			[]params.FileInspector{accept},
+			map[string]params.FileInspector{"clamav": reject},
+			perror.New("file", "infected"),
+		},
+		{
+			"named inspectors should only run when requested",
+			`json:"file"`,
+			nil,
+			map[string]params.FileInspector{"clamav": reject},
+			nil,
+		},
+		{
+			"failing inspector should return the error",
+			`json:"file"`,
+			[]params.FileInspector{fail},
+			nil,
+			systemErr,
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.description, func(t *testing.T) {
+			t.Parallel()
+
+			// init the mocks
+			mockCtrl := gomock.NewController(t)
+			defer mockCtrl.Finish()
+
+			// Expectations
+			cwd, _ := os.Getwd()
+			fileHeader, fileData := testformfile.NewMultipartData(t, cwd, "black_pixel.png")
+			defer fileData.Close()
+			fileHolder := mockformfile.NewMockFileHolder(mockCtrl)
+			fileHolder.EXPECT().FormFile("file").Return(fileData, fileHeader, nil)
+
+			// Call the function to test
+			s := struct {
+				File *formfile.FormFile
+			}{}
+			paramList := reflect.ValueOf(&s).Elem()
+			p := newParamFromStructValue(&paramList, 0)
+			tag := reflect.StructTag(tc.tag)
+			p.Tags = &tag
+			p.FileInspectors = tc.global
+			p.NamedFileInspectors = tc.named
+
+			err := p.SetFile(fileHolder)
+			if tc.expectedError != nil {
+				require.Error(t, err, "Expected SetFile to return an error")
+				assert.Equal(t, tc.expectedError, err, "Wrong error returned")
+			} else {
+				require.NoError(t, err, "Expected SetFile not to return an error")
+				assert.NotNil(t, s.File, "Expected File NOT to be nil")
+			}
+		})
+	}
+
+	t.Run("unknown inspector", func(t *testing.T) {
+		t.Parallel()
+
+		// init the mocks
+		mockCtrl := gomock.NewController(t)
+		defer mockCtrl.Finish()
+
+		// Expectations
+		cwd, _ := os.Getwd()
+		fileHeader, fileData := testformfile.NewMultipartData(t, cwd, "black_pixel.png")
+		defer fileData.Close()
+		fileHolder := mockformfile.NewMockFileHolder(mockCtrl)
+		fileHolder.EXPECT().FormFile("file").Return(fileData, fileHeader, nil)
+
+		s := struct {
+			File *formfile.FormFile `json:"file" inspect:"nope"`
+		}{}
+		paramList := reflect.ValueOf(&s).Elem()
+		p := newParamFromStructValue(&paramList, 0)
+		err := p.SetFile(fileHolder)
+		require.Error(t, err, "Expected SetFile to return an error")
+		assert.Contains(t, err.Error(), "file inspector nope for field File does not exist", "SetFile() failed with an unexpected error")
+	})
+}
+
+func subTestSetFileMultipleSingleHolder(t *testing.T) {
+	t.Parallel()
+
+	type strct struct {
+		Files []*formfile.FormFile `from:"file" json:"files"`
+	}
+
+	// Init the mocks
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	// Set the expectations
+	fileHolder := mockformfile.NewMockFileHolder(mockCtrl)
+
+	// Call the function to test
+	paramList := reflect.ValueOf(&strct{}).Elem()
+	p := newParamFromStructValue(&paramList, 0)
+	err := p.SetFile(fileHolder)
+	require.Error(t, err, "Expected SetFile to return an error")
+	assert.Contains(t, err.Error(), "is not a formfile.MultiFileHolder", "SetFile() failed with an unexpected error")
 }
 
 func subTestSetFileFormFileFail(t *testing.T) {
@@ -251,6 +748,8 @@ func subTestSetFileParamValidImage(t *testing.T) {
 
 				if tc.filename != "" {
 					assert.Equal(t, tc.expectedMime, tc.s.File.Mime, "Wrong mime type")
+					assert.Equal(t, 1, tc.s.File.Width, "Wrong width")
+					assert.Equal(t, 1, tc.s.File.Height, "Wrong height")
 				}
 			}
 		})
@@ -769,6 +1268,18 @@ func subTestsSetValueStringSlice(t *testing.T) {
 			url.Values{},
 			nil,
 		},
+		{
+			"provided using an alias",
+			`json:"slice" alias:"list,items"`,
+			url.Values{"items": []string{"1", "2"}},
+			[]string{"1", "2"},
+		},
+		{
+			"name preferred over alias",
+			`json:"slice" alias:"list"`,
+			url.Values{"list": []string{"1"}, "slice": []string{"2"}},
+			[]string{"2"},
+		},
 	}
 
 	for _, tc := range testCases {
@@ -1247,3 +1758,86 @@ func newParamFromStructValue(paramList *reflect.Value, paramPos int) *params.Par
 		Tags:  &tags,
 	}
 }
+
+func subTestSetValueKeyMatching(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		description     string
+		tag             string
+		strategy        params.NamingStrategy
+		caseInsensitive bool
+		source          url.Values
+		expectedValue   string
+	}{
+		{
+			"naming strategy on untagged field",
+			``,
+			params.SnakeCase,
+			false,
+			url.Values{"user_id": []string{"snake"}, "UserID": []string{"verbatim"}},
+			"snake",
+		},
+		{
+			"naming strategy ignored on tagged field",
+			`json:"id"`,
+			params.SnakeCase,
+			false,
+			url.Values{"id": []string{"tagged"}, "user_id": []string{"snake"}},
+			"tagged",
+		},
+		{
+			"case sensitive by default",
+			`json:"user_id"`,
+			nil,
+			false,
+			url.Values{"User_ID": []string{"value"}},
+			"",
+		},
+		{
+			"case insensitive",
+			`json:"user_id"`,
+			nil,
+			true,
+			url.Values{"User_ID": []string{"value"}},
+			"value",
+		},
+		{
+			"exact match preferred when case insensitive",
+			`json:"user_id"`,
+			nil,
+			true,
+			url.Values{"USER_ID": []string{"folded"}, "user_id": []string{"exact"}},
+			"exact",
+		},
+		{
+			"case insensitive aliases",
+			`json:"user_id" alias:"userId"`,
+			nil,
+			true,
+			url.Values{"USERID": []string{"value"}},
+			"value",
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.description, func(t *testing.T) {
+			t.Parallel()
+			s := struct {
+				UserID string
+			}{}
+
+			paramList := reflect.ValueOf(&s).Elem()
+			p := newParamFromStructValue(&paramList, 0)
+			tag := reflect.StructTag(tc.tag)
+			p.Tags = &tag
+			p.NamingStrategy = tc.strategy
+			p.CaseInsensitive = tc.caseInsensitive
+
+			err := p.SetValue(tc.source)
+			require.NoError(t, err, "SetValue() should not have fail")
+			assert.Equal(t, tc.expectedValue, s.UserID, "SetValue() did not set the expected value")
+		})
+	}
+}
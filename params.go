@@ -8,11 +8,57 @@ import (
 
 	"github.com/Nivl/go-params/formfile"
 	"github.com/Nivl/go-params/perror"
+	"github.com/Nivl/go-types/slices"
 )
 
 // Params is a struct used to parse and extract params from an other struct
 type Params struct {
 	data interface{}
+
+	// fileInspectors contains the inspectors to run on every file
+	fileInspectors []FileInspector
+
+	// namedFileInspectors contains the inspectors that can be requested
+	// by a file using the inspect tag
+	namedFileInspectors map[string]FileInspector
+
+	// strict means the query and form params that don't match any
+	// fields will be rejected
+	strict bool
+
+	// warnings contains the warnings recorded during the last parsing
+	warnings []Warning
+
+	// warningHook is called every time a warning is recorded
+	warningHook WarningHook
+
+	// namingStrategy is used to name the fields that have no names
+	// set in their tags
+	namingStrategy NamingStrategy
+
+	// caseInsensitive means the keys of the sources are matched without
+	// taking care of their case
+	caseInsensitive bool
+}
+
+// strictSources contains the sources checked in strict mode
+var strictSources = []string{"query", "form"}
+
+// parseState contains the data shared by all the levels of a parsing
+type parseState struct {
+	sources    map[string]url.Values
+	fileHolder formfile.FileHolder
+
+	// consumed contains, per source, the keys that matched a field
+	consumed map[string]map[string]bool
+}
+
+// consume marks a key of a source as used by a field
+func (s *parseState) consume(source, key string) {
+	if s.consumed[source] == nil {
+		s.consumed[source] = map[string]bool{}
+	}
+	s.consumed[source][key] = true
 }
 
 // New creates a new Params object from a struct
@@ -22,14 +68,124 @@ func New(data interface{}) *Params {
 	}
 }
 
-// Parse fills the paramsStruct using the provided sources
+// AddFileInspector registers an inspector that will be run on every file
+func (p *Params) AddFileInspector(inspector FileInspector) {
+	p.fileInspectors = append(p.fileInspectors, inspector)
+}
+
+// RegisterFileInspector registers an inspector that will be run on the files
+// requesting it using inspect:"name"
+func (p *Params) RegisterFileInspector(name string, inspector FileInspector) {
+	if p.namedFileInspectors == nil {
+		p.namedFileInspectors = map[string]FileInspector{}
+	}
+	p.namedFileInspectors[name] = inspector
+}
+
+// target returns the struct to work on.
+// ErrNilTarget or ErrNotStructPointer are returned if the data are not
+// usable. A struct is accepted if allowNonPointer is true
+func (p *Params) target(allowNonPointer bool) (reflect.Value, error) {
+	if p.data == nil {
+		return reflect.Value{}, ErrNilTarget
+	}
+
+	value := reflect.ValueOf(p.data)
+	if value.Kind() != reflect.Ptr {
+		if allowNonPointer && value.Kind() == reflect.Struct {
+			return value, nil
+		}
+		return reflect.Value{}, fmt.Errorf("%w, got %T", ErrNotStructPointer, p.data)
+	}
+	if value.IsNil() {
+		return reflect.Value{}, fmt.Errorf("%w, got a nil %T", ErrNilTarget, p.data)
+	}
+	if value.Elem().Kind() != reflect.Struct {
+		return reflect.Value{}, fmt.Errorf("%w, got %T", ErrNotStructPointer, p.data)
+	}
+	return value.Elem(), nil
+}
+
+// SetStrict enables or disables the strict mode. When enabled, Parse
+// returns an error for every query or form param that doesn't match
+// any fields
+func (p *Params) SetStrict(strict bool) {
+	p.strict = strict
+}
+
+// SetNamingStrategy sets the strategy used to name the fields that have
+// no names set in their tags. The Go field name is used by default
+func (p *Params) SetNamingStrategy(strategy NamingStrategy) {
+	p.namingStrategy = strategy
+}
+
+// SetCaseInsensitive enables or disables the case insensitive matching
+// of the keys of the sources
+func (p *Params) SetCaseInsensitive(caseInsensitive bool) {
+	p.caseInsensitive = caseInsensitive
+}
+
+// fieldName returns the name of a field that has no names set in its tags
+func (p *Params) fieldName(info reflect.StructField) string {
+	if p.namingStrategy != nil {
+		return p.namingStrategy(info.Name)
+	}
+	return info.Name
+}
+
+// Warnings returns the warnings recorded during the last call to Parse,
+// like the use of deprecated params
+func (p *Params) Warnings() []Warning {
+	return p.warnings
+}
+
+// SetWarningHook sets a function that will be called every time a
+// warning is recorded
+func (p *Params) SetWarningHook(hook WarningHook) {
+	p.warningHook = hook
+}
+
+// warn records a warning and sends it to the hook
+func (p *Params) warn(w Warning) {
+	p.warnings = append(p.warnings, w)
+	if p.warningHook != nil {
+		p.warningHook(w)
+	}
+}
+
+// Parse fills the paramsStruct using the provided sources.
+// ErrNilTarget or ErrNotStructPointer are returned if the data provided
+// to New() is not a valid pointer to a struct
 func (p *Params) Parse(sources map[string]url.Values, fileHolder formfile.FileHolder) error {
-	paramList := reflect.Indirect(reflect.ValueOf(p.data))
-	err := p.parseRecursive(paramList, sources, fileHolder)
+	paramList, err := p.target(false)
 	if err != nil {
 		return err
 	}
 
+	p.warnings = nil
+	if parser, ok := p.data.(Parser); ok && p.usesGeneratedCode() {
+		// We use the code generated by paramsgen
+		if err := parser.ParseParams(sources, fileHolder); err != nil {
+			return err
+		}
+	} else {
+		state := &parseState{
+			sources:    sources,
+			fileHolder: fileHolder,
+			consumed:   map[string]map[string]bool{},
+		}
+		err = p.parseRecursive(paramList, state)
+		if err != nil {
+			return err
+		}
+
+		if p.strict {
+			if err := unknownParams(state); err != nil {
+				return err
+			}
+		}
+	}
+
 	// If there's a custom validator we'll use it
 	if validator, ok := p.data.(CustomValidation); ok {
 		isValid, field, err := validator.IsValid()
@@ -41,7 +197,11 @@ func (p *Params) Parse(sources map[string]url.Values, fileHolder formfile.FileHo
 	return nil
 }
 
-func (p *Params) parseRecursive(paramList reflect.Value, sources map[string]url.Values, fileHolder formfile.FileHolder) error {
+func (p *Params) parseRecursive(paramList reflect.Value, state *parseState) error {
+	// files that need to be checked against a checksum. The checks are
+	// done once all the fields have been parsed
+	withChecksum := []*Param{}
+
 	nbParams := paramList.NumField()
 	for i := 0; i < nbParams; i++ {
 		value := paramList.Field(i)
@@ -54,8 +214,16 @@ func (p *Params) parseRecursive(paramList reflect.Value, sources map[string]url.
 		}
 
 		// Handle embedded struct
-		if value.Kind() == reflect.Struct && info.Anonymous {
-			err := p.parseRecursive(value, sources, fileHolder)
+		if isEmbeddedStruct(info) {
+			// we malloc the struct if we have a nil pointer
+			if value.Kind() == reflect.Ptr {
+				if value.IsNil() {
+					value.Set(reflect.New(info.Type.Elem()))
+				}
+				value = value.Elem()
+			}
+
+			err := p.parseRecursive(value, state)
 			if err != nil {
 				return err
 			}
@@ -81,47 +249,262 @@ func (p *Params) parseRecursive(paramList reflect.Value, sources map[string]url.
 		}
 
 		param := &Param{
-			Value: &value,
-			Info:  &info,
-			Tags:  &tags,
+			Value:               &value,
+			Info:                &info,
+			Tags:                &tags,
+			FileInspectors:      p.fileInspectors,
+			NamedFileInspectors: p.namedFileInspectors,
+			NamingStrategy:      p.namingStrategy,
+			CaseInsensitive:     p.caseInsensitive,
 		}
 
 		// the "file" source is a special case as it's not part of the sources object
 		if paramLocation == "file" {
-			if err := param.SetFile(fileHolder); err != nil {
+			if err := param.SetFile(state.fileHolder); err != nil {
 				return err
 			}
+			if tags.Get("checksum_field") != "" {
+				withChecksum = append(withChecksum, param)
+			}
 		} else {
-			source, found := sources[paramLocation]
-			if !found {
-				return fmt.Errorf("source %s for field %s does not exist", paramLocation, info.Name)
+			if err := p.setValueFromSources(param, strings.Split(paramLocation, ","), state); err != nil {
+				return err
 			}
+		}
+	}
 
-			if err := param.SetValue(source); err != nil {
-				return err
+	for _, param := range withChecksum {
+		checksumField := param.Tags.Get("checksum_field")
+		checksum, found, err := p.stringFieldByName(paramList, checksumField)
+		if err == nil && !found {
+			err = fmt.Errorf("field %s does not exist", checksumField)
+		}
+		if err != nil {
+			return fmt.Errorf("checksum of field %s: %s", param.Info.Name, err.Error())
+		}
+		if err := param.VerifyChecksum(checksum); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// setValueFromSources sets the value of the param using the first source,
+// in the provided order, that contains the param
+func (p *Params) setValueFromSources(param *Param, locations []string, state *parseState) error {
+	opts, err := NewOptions(param.Tags)
+	if err != nil {
+		return err
+	}
+
+	// The tag needs to be ignored
+	if opts.Ignore {
+		return nil
+	}
+
+	if opts.Name == "" {
+		opts.Name = param.defaultName()
+	}
+
+	var source url.Values
+	var sourceKey string
+	for i, location := range locations {
+		location = strings.TrimSpace(location)
+		locations[i] = location
+
+		if location == "file" && len(locations) > 1 {
+			return fmt.Errorf("field %s: the file source cannot be combined with other sources", param.Info.Name)
+		}
+		s, found := state.sources[location]
+		if !found {
+			return fmt.Errorf("source %s for field %s does not exist", location, param.Info.Name)
+		}
+
+		keys := param.matchingKeys(opts, s)
+		if len(keys) == 0 {
+			continue
+		}
+		// all the keys of the field are known, even the ones we
+		// don't use
+		for _, k := range keys {
+			state.consume(location, k)
+		}
+		key := keys[0]
+
+		if source == nil {
+			source = s
+			sourceKey = key
+			continue
+		}
+		if err := opts.ValidateConflict(source[sourceKey], s[key]); err != nil {
+			return err
+		}
+	}
+
+	// None of the sources contains the param, we use the first one to
+	// apply the default value and check if the param is required
+	if source == nil {
+		return param.SetValue(state.sources[locations[0]])
+	}
+
+	if opts.IsDeprecatedKey(sourceKey) {
+		p.warn(Warning{
+			Field:   opts.Name,
+			Key:     sourceKey,
+			Message: opts.Deprecated,
+		})
+	}
+	return param.SetValue(source)
+}
+
+// equalValues checks if two lists of values are identical
+func equalValues(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// unknownParams returns a perror.List containing all the query and form
+// params that were not used by any fields
+func unknownParams(state *parseState) error {
+	errs := perror.List{}
+	for _, sourceType := range strictSources {
+		source := state.sources[sourceType]
+		for _, key := range sortedKeys(source) {
+			if !state.consumed[sourceType][key] {
+				errs = append(errs, perror.New(key, ErrMsgUnknownParameter))
 			}
 		}
 	}
 
+	if len(errs) > 0 {
+		return errs
+	}
 	return nil
 }
 
+// stringFieldByName returns the value of the string (or *string) field
+// having the given param name. The embedded structs are searched as well.
+// An empty string is returned for nil pointers
+func (p *Params) stringFieldByName(paramList reflect.Value, name string) (value string, found bool, err error) {
+	nbParams := paramList.NumField()
+	for i := 0; i < nbParams; i++ {
+		info := paramList.Type().Field(i)
+
+		if isEmbeddedStruct(info) {
+			embedded := reflect.Indirect(paramList.Field(i))
+			if !embedded.IsValid() {
+				continue
+			}
+			value, found, err = p.stringFieldByName(embedded, name)
+			if err != nil || found {
+				return value, found, err
+			}
+			continue
+		}
+
+		opts, err := NewOptions(&info.Tag)
+		if err != nil {
+			return "", false, err
+		}
+		if opts.Ignore {
+			continue
+		}
+		if opts.Name == "" {
+			opts.Name = p.fieldName(info)
+		}
+		if opts.Name != name {
+			continue
+		}
+
+		field := paramList.Field(i)
+		if field.Kind() == reflect.Ptr && field.IsNil() {
+			return "", true, nil
+		}
+		field = reflect.Indirect(field)
+		if field.Kind() != reflect.String {
+			return "", true, fmt.Errorf("field %s is not a string", info.Name)
+		}
+		return field.String(), true, nil
+	}
+	return "", false, nil
+}
+
+// isEmbeddedStruct checks if the field is an embedded struct, or an
+// embedded pointer to a struct
+func isEmbeddedStruct(info reflect.StructField) bool {
+	if !info.Anonymous {
+		return false
+	}
+
+	fieldType := info.Type
+	if fieldType.Kind() == reflect.Ptr {
+		fieldType = fieldType.Elem()
+	}
+	return fieldType.Kind() == reflect.Struct
+}
+
+// extractedData contains all the data extracted from a params struct
+type extractedData struct {
+	sources map[string]url.Values
+	files   map[string]*formfile.FormFile
+
+	// multipleFiles contains the []*formfile.FormFile fields, which cannot
+	// be stored in files
+	multipleFiles map[string][]*formfile.FormFile
+}
+
 // Extract extracts the data from the paramsStruct and returns them
-// as a map of url.Values
+// as a map of url.Values, which can be read back by Parse. The empty
+// strings and the empty slices of the params having a default value are
+// the exception, since Parse replaces them by the default value.
+// Empty maps are returned if the data provided to New() is not a struct
+// or a pointer to a struct. Use ExtractE() to get an error instead
 func (p *Params) Extract() (sources map[string]url.Values, files map[string]*formfile.FormFile) {
-	sources = map[string]url.Values{}
-	files = map[string]*formfile.FormFile{}
+	data, _ := p.extract()
+	return data.sources, data.files
+}
 
-	if p.data == nil {
-		return sources, files
+// ExtractE works like Extract() but returns ErrNilTarget or
+// ErrNotStructPointer if the data provided to New() is not a struct or
+// a pointer to a struct, and an error if a tag is invalid
+func (p *Params) ExtractE() (sources map[string]url.Values, files map[string]*formfile.FormFile, err error) {
+	data, err := p.extract()
+	return data.sources, data.files, err
+}
+
+// extract extracts all the data from the paramsStruct
+func (p *Params) extract() (*extractedData, error) {
+	data := &extractedData{
+		sources:       map[string]url.Values{},
+		files:         map[string]*formfile.FormFile{},
+		multipleFiles: map[string][]*formfile.FormFile{},
+	}
+
+	paramList, err := p.target(true)
+	if err != nil {
+		return data, err
 	}
 
-	paramList := reflect.Indirect(reflect.ValueOf(p.data))
-	p.extractRecursive(paramList, sources, files)
-	return sources, files
+	if extractor, ok := p.data.(Extractor); ok && p.usesGeneratedCode() {
+		// We use the code generated by paramsgen
+		data.sources, data.files, data.multipleFiles, err = extractor.ExtractParams()
+		return data, err
+	}
+
+	err = p.extractRecursive(paramList, data)
+	return data, err
 }
 
-func (p *Params) extractRecursive(paramList reflect.Value, sources map[string]url.Values, files map[string]*formfile.FormFile) {
+func (p *Params) extractRecursive(paramList reflect.Value, data *extractedData) error {
+	sources := data.sources
 	nbParams := paramList.NumField()
 	for i := 0; i < nbParams; i++ {
 		value := paramList.Field(i)
@@ -133,36 +516,40 @@ func (p *Params) extractRecursive(paramList reflect.Value, sources map[string]ur
 			continue
 		}
 
-		// We get the name from the json tag
+		// We get the name from the param tag (or the json tag)
 		fieldName := ""
-		jsonOpts := strings.Split(tags.Get("json"), ",")
-		if len(jsonOpts) > 0 {
-			if jsonOpts[0] == "-" {
+		nameOpts := nameTagOptions(&tags)
+		if len(nameOpts) > 0 {
+			if nameOpts[0] == "-" {
 				continue
 			}
-			fieldName = jsonOpts[0]
+			fieldName = nameOpts[0]
 		}
 		if fieldName == "" {
-			fieldName = info.Name
+			fieldName = p.fieldName(info)
 		}
 		// if the field has the omitempty option we want to honor it
-		omitempty := false
-		if len(jsonOpts) > 1 {
-			for _, opt := range jsonOpts {
-				if opt == "omitempty" {
-					omitempty = true
-				}
-			}
-		}
+		omitempty := slices.HasString("omitempty", nameOpts[1:])
 
 		// Handle embedded struct
-		if reflect.Indirect(value).Kind() == reflect.Struct && info.Anonymous {
-			p.extractRecursive(value, sources, files)
+		if isEmbeddedStruct(info) {
+			if err := p.extractRecursive(reflect.Indirect(value), data); err != nil {
+				return err
+			}
 			continue
 		}
 
+		// The options are used to extract the values the way Parse
+		// would store them
+		opts, err := NewOptions(&tags)
+		if err != nil {
+			return fmt.Errorf("field %s: %s", info.Name, err.Error())
+		}
+
 		// We get the source type (url, query, form, ...) and add the value
+		// When multiple sources are set, we use the first one
 		sourceType := strings.ToLower(tags.Get("from"))
+		sourceType = strings.TrimSpace(strings.Split(sourceType, ",")[0])
 		if sourceType == "" {
 			sourceType = "unknown"
 		}
@@ -173,7 +560,13 @@ func (p *Params) extractRecursive(paramList reflect.Value, sources map[string]ur
 
 		// Special cases for files
 		if info.Type.String() == "*formfile.FormFile" {
-			files[fieldName] = value.Interface().(*formfile.FormFile)
+			data.files[fieldName] = value.Interface().(*formfile.FormFile)
+			continue
+		}
+		if info.Type.String() == "[]*formfile.FormFile" {
+			if !value.IsNil() {
+				data.multipleFiles[fieldName] = value.Interface().([]*formfile.FormFile)
+			}
 			continue
 		}
 
@@ -188,7 +581,7 @@ func (p *Params) extractRecursive(paramList reflect.Value, sources map[string]ur
 				}
 
 				for i := 0; i < totalElems; i++ {
-					stringValue := fmt.Sprintf("%v", value.Index(i).Interface())
+					stringValue := opts.ApplyTransformations(formatValue(field.Index(i)))
 					sources[sourceType].Add(fieldName, stringValue)
 				}
 			}
@@ -196,13 +589,14 @@ func (p *Params) extractRecursive(paramList reflect.Value, sources map[string]ur
 			continue
 		}
 
-		// we cast the value to string (works with any stringers)
-		valueStr := fmt.Sprintf("%v", field.Interface())
-		isZeroValue := reflect.Zero(field.Type()).Interface() == field.Interface()
+		// we cast the value to string (works with any Formatters or stringers)
+		valueStr := opts.ApplyTransformations(formatValue(field))
 
-		// if the omitempty option is set, we wont set any zero value
-		if !omitempty || (omitempty && !isZeroValue) {
+		// if the omitempty option is set, we wont set any zero value,
+		// unless Parse would replace it by the default value
+		if !omitempty || !field.IsZero() || tags.Get("default") != "" {
 			sources[sourceType].Set(fieldName, valueStr)
 		}
 	}
+	return nil
 }
@@ -1,8 +1,12 @@
 package params_test
 
 import (
+	"bytes"
 	"errors"
+	"mime/multipart"
 	"net/http"
+	"net/http/httptest"
+	"net/textproto"
 	"net/url"
 	"os"
 	"strconv"
@@ -12,6 +16,7 @@ import (
 	"github.com/Nivl/go-params/formfile"
 	"github.com/Nivl/go-params/formfile/mockformfile"
 	"github.com/Nivl/go-params/formfile/testformfile"
+	"github.com/Nivl/go-params/perror"
 	"github.com/Nivl/go-types/date"
 	"github.com/Nivl/go-types/ptrs"
 	gomock "github.com/golang/mock/gomock"
@@ -38,15 +43,101 @@ func TestParamsParse(t *testing.T) {
 	t.Run("field with unexisting source", subTestFieldWithUnexistingSource)
 	t.Run("field not exported", subTestFieldNotExported)
 	t.Run("embedded struct", subTestEmbeddedStruct)
+	t.Run("embedded pointer to struct", subTestEmbeddedPointerStruct)
 	t.Run("embedded struct with custon validation", subTestEmbeddedStructWithCustomValidation)
 	t.Run("custom validation", subTestCustomValidation)
 	t.Run("file handling", subTestFileUpload)
 	t.Run("file handling", subTestFileUpload)
+	t.Run("strict mime on a multipart request", subTestStrictMimeUpload)
+	t.Run("file checksum", subTestFileChecksum)
+	t.Run("file checksum in an embedded struct", subTestFileChecksumEmbedded)
+	t.Run("file inspectors", subTestFileInspectors)
+	t.Run("files from memory", subTestFilesFromMemory)
+	t.Run("strict mode", subTestStrictMode)
+	t.Run("multiple sources", subTestMultipleSources)
+	t.Run("aliases and deprecations", subTestAliases)
+	t.Run("naming strategy", subTestNamingStrategy)
+	t.Run("param tag", subTestParamTag)
 }
 
 func TestParamsExtract(t *testing.T) {
 	t.Run("extract", subTestExtraction)
 	t.Run("nil value", subTestExtractNil)
+	t.Run("embedded pointer", subTestExtractEmbeddedPointer)
+	t.Run("naming strategy", subTestExtractNamingStrategy)
+	t.Run("param tag", subTestExtractParamTag)
+}
+
+func TestSetTagKey(t *testing.T) {
+	// Not parallel since the tag key is global
+	type tagKeyParams struct {
+		Name string `from:"query" json:"name" form:"full_name"`
+	}
+
+	params.SetTagKey("form")
+	defer params.SetTagKey("param")
+
+	s := &tagKeyParams{}
+	err := params.New(s).Parse(map[string]url.Values{
+		"query": url.Values{"name": []string{"json"}, "full_name": []string{"form"}},
+	}, nil)
+	require.NoError(t, err, "Parse() should have succeed")
+	assert.Equal(t, "form", s.Name, "the form tag should have been used")
+
+	sources, _ := params.New(s).Extract()
+	assert.Equal(t, "form", sources["query"].Get("full_name"), "the form tag should have been used")
+}
+
+func TestParamsInvalidTarget(t *testing.T) {
+	type strct struct {
+		Name string `from:"query" json:"name"`
+	}
+	var nilStruct *strct
+	validStruct := &strct{}
+
+	testCases := []struct {
+		description     string
+		data            interface{}
+		parseError      error
+		extractError    error
+		checkExtraction bool
+	}{
+		{"nil", nil, params.ErrNilTarget, params.ErrNilTarget, false},
+		{"nil pointer", nilStruct, params.ErrNilTarget, params.ErrNilTarget, false},
+		{"struct", strct{Name: "name"}, params.ErrNotStructPointer, nil, true},
+		{"map", map[string]string{}, params.ErrNotStructPointer, params.ErrNotStructPointer, false},
+		{"pointer to map", &map[string]string{}, params.ErrNotStructPointer, params.ErrNotStructPointer, false},
+		{"scalar", 42, params.ErrNotStructPointer, params.ErrNotStructPointer, false},
+		{"pointer to scalar", ptrs.NewInt(42), params.ErrNotStructPointer, params.ErrNotStructPointer, false},
+		{"pointer to pointer", &validStruct, params.ErrNotStructPointer, params.ErrNotStructPointer, false},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.description, func(t *testing.T) {
+			t.Parallel()
+
+			sources := map[string]url.Values{"query": {"name": []string{"name"}}}
+			err := params.New(tc.data).Parse(sources, nil)
+			require.Error(t, err, "Parse() should have failed")
+			assert.True(t, errors.Is(err, tc.parseError), "Parse() returned an unexpected error: %s", err)
+
+			extracted, _, err := params.New(tc.data).ExtractE()
+			if tc.extractError != nil {
+				require.Error(t, err, "ExtractE() should have failed")
+				assert.True(t, errors.Is(err, tc.extractError), "ExtractE() returned an unexpected error: %s", err)
+			} else {
+				require.NoError(t, err, "ExtractE() should not have failed")
+			}
+
+			if tc.checkExtraction {
+				assert.Equal(t, "name", extracted["query"].Get("name"), "ExtractE() returned unexpected data")
+			}
+
+			// Extract() should never panic
+			assert.NotPanics(t, func() { params.New(tc.data).Extract() }, "Extract() should not have panicked")
+		})
+	}
 }
 
 func subTestValidStruct(t *testing.T) {
@@ -456,3 +547,626 @@ func subTestFileUpload(t *testing.T) {
 		})
 	}
 }
+
+func subTestStrictMimeUpload(t *testing.T) {
+	t.Parallel()
+
+	type strct struct {
+		File *formfile.FormFile `from:"file" json:"file" params:"required,strict_mime"`
+	}
+
+	testCases := []struct {
+		description   string
+		filename      string
+		contentType   string
+		content       []byte
+		expectedError error
+	}{
+		{
+			"small text file sent as text/plain should work",
+			"note.txt",
+			"text/plain",
+			[]byte("a short note\n"),
+			nil,
+		},
+		{
+			"small text file sent as an image should fail",
+			"note.txt",
+			"image/png",
+			[]byte("a short note\n"),
+			perror.New("file", params.ErrMsgMimeMismatch),
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.description, func(t *testing.T) {
+			t.Parallel()
+
+			// create the multipart request
+			body := &bytes.Buffer{}
+			w := multipart.NewWriter(body)
+			header := textproto.MIMEHeader{}
+			header.Set("Content-Disposition", `form-data; name="file"; filename="`+tc.filename+`"`)
+			header.Set("Content-Type", tc.contentType)
+			part, err := w.CreatePart(header)
+			require.NoError(t, err, "CreatePart() should have worked")
+			_, err = part.Write(tc.content)
+			require.NoError(t, err, "Write() should have worked")
+			require.NoError(t, w.Close(), "Close() should have worked")
+
+			req := httptest.NewRequest(http.MethodPost, "/", body)
+			req.Header.Set("Content-Type", w.FormDataContentType())
+			require.NoError(t, req.ParseMultipartForm(1<<20), "ParseMultipartForm() should have worked")
+
+			s := strct{}
+			err = params.New(&s).Parse(nil, req)
+			if tc.expectedError != nil {
+				require.Error(t, err, "Expected Parse to return an error")
+				assert.Equal(t, tc.expectedError, err, "Wrong error returned")
+				return
+			}
+			require.NoError(t, err, "Expected Parse not to return an error")
+			require.NotNil(t, s.File, "Expected the file to be set")
+			assert.Equal(t, "text/plain; charset=utf-8", s.File.Mime, "Wrong mime type")
+		})
+	}
+}
+
+func subTestFileChecksum(t *testing.T) {
+	t.Parallel()
+
+	type strct struct {
+		File     *formfile.FormFile `from:"file" json:"file" params:"required" checksum_field:"checksum"`
+		Checksum *string            `from:"form" json:"checksum"`
+	}
+
+	testCases := []struct {
+		description      string
+		checksum         string
+		expectedErrorMsg string
+	}{
+		{"No checksum should work", "", ""},
+		{"Valid SHA-256 should work", "5c91de0e65daa63c59a8584de5dbb01d9fdd5c0461cfd2af96f56e00a116b709", ""},
+		{"Valid MD5 should work", "43F7F1C29E856BF18476DB5F74B261DC", ""},
+		{"Invalid checksum should fail", "nope", params.ErrMsgInvalidChecksum},
+		{"Wrong SHA-256 should fail", "0c91de0e65daa63c59a8584de5dbb01d9fdd5c0461cfd2af96f56e00a116b709", params.ErrMsgChecksumMismatch},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.description, func(t *testing.T) {
+			t.Parallel()
+
+			// init the mocks
+			mockCtrl := gomock.NewController(t)
+			defer mockCtrl.Finish()
+
+			// create the multipart data
+			cwd, _ := os.Getwd()
+			imageHeader, imageFile := testformfile.NewMultipartData(t, cwd, "black_pixel.png")
+			defer imageFile.Close()
+
+			// Expectations
+			fileHolder := mockformfile.NewMockFileHolder(mockCtrl)
+			fileHolder.EXPECT().FormFile("file").Return(imageFile, imageHeader, nil)
+
+			form := url.Values{}
+			if tc.checksum != "" {
+				form.Set("checksum", tc.checksum)
+			}
+
+			s := strct{}
+			p := params.New(&s)
+			err := p.Parse(map[string]url.Values{"form": form}, fileHolder)
+
+			if tc.expectedErrorMsg != "" {
+				require.EqualError(t, err, tc.expectedErrorMsg, "Parse returned an unexpected error")
+			} else {
+				require.NoError(t, err, "Expected Parse not to return an error")
+				assert.Equal(t, "5c91de0e65daa63c59a8584de5dbb01d9fdd5c0461cfd2af96f56e00a116b709", s.File.SHA256, "Wrong SHA-256")
+				assert.Equal(t, "43f7f1c29e856bf18476db5f74b261dc", s.File.MD5, "Wrong MD5")
+			}
+		})
+	}
+}
+
+// ChecksumParams is embedded to hold the checksum of a file
+type ChecksumParams struct {
+	Checksum *string `from:"form" json:"checksum"`
+}
+
+func subTestFileChecksumEmbedded(t *testing.T) {
+	t.Parallel()
+
+	type strct struct {
+		ChecksumParams
+		File *formfile.FormFile `from:"file" json:"file" params:"required" checksum_field:"checksum"`
+	}
+
+	type unknownField struct {
+		ChecksumParams
+		File *formfile.FormFile `from:"file" json:"file" params:"required" checksum_field:"sha256"`
+	}
+
+	testCases := []struct {
+		description      string
+		s                interface{}
+		checksum         string
+		expectedErrorMsg string
+	}{
+		{"Valid checksum should work", &strct{}, "5c91de0e65daa63c59a8584de5dbb01d9fdd5c0461cfd2af96f56e00a116b709", ""},
+		{"Wrong checksum should fail", &strct{}, "43F7F1C29E856BF18476DB5F74B261DD", params.ErrMsgChecksumMismatch},
+		{"Unknown checksum field should fail", &unknownField{}, "", "checksum of field File: field sha256 does not exist"},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.description, func(t *testing.T) {
+			t.Parallel()
+
+			// init the mocks
+			mockCtrl := gomock.NewController(t)
+			defer mockCtrl.Finish()
+
+			// create the multipart data
+			cwd, _ := os.Getwd()
+			imageHeader, imageFile := testformfile.NewMultipartData(t, cwd, "black_pixel.png")
+			defer imageFile.Close()
+
+			// Expectations
+			fileHolder := mockformfile.NewMockFileHolder(mockCtrl)
+			fileHolder.EXPECT().FormFile("file").Return(imageFile, imageHeader, nil)
+
+			form := url.Values{}
+			if tc.checksum != "" {
+				form.Set("checksum", tc.checksum)
+			}
+
+			err := params.New(tc.s).Parse(map[string]url.Values{"form": form}, fileHolder)
+			if tc.expectedErrorMsg != "" {
+				require.EqualError(t, err, tc.expectedErrorMsg, "Parse returned an unexpected error")
+				return
+			}
+			require.NoError(t, err, "Expected Parse not to return an error")
+		})
+	}
+}
+
+func subTestFileInspectors(t *testing.T) {
+	t.Parallel()
+
+	type strct struct {
+		Avatar   *formfile.FormFile `from:"file" json:"avatar" inspect:"policy"`
+		Document *formfile.FormFile `from:"file" json:"document"`
+	}
+
+	// init the mocks
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	// create the multipart data
+	cwd, _ := os.Getwd()
+	imageHeader, imageFile := testformfile.NewMultipartData(t, cwd, "black_pixel.png")
+	defer imageFile.Close()
+	licenseHeader, licenseFile := testformfile.NewMultipartData(t, cwd, "LICENSE")
+	defer licenseFile.Close()
+
+	// Expectations
+	fileHolder := mockformfile.NewMockFileHolder(mockCtrl)
+	fileHolder.EXPECT().FormFile("avatar").Return(imageFile, imageHeader, nil)
+	fileHolder.EXPECT().FormFile("document").Return(licenseFile, licenseHeader, nil)
+
+	scanned := []string{}
+	policyChecked := []string{}
+
+	s := strct{}
+	p := params.New(&s)
+	p.AddFileInspector(params.FileInspectorFunc(func(field string, file *formfile.FormFile) error {
+		scanned = append(scanned, field)
+		return nil
+	}))
+	p.RegisterFileInspector("policy", params.FileInspectorFunc(func(field string, file *formfile.FormFile) error {
+		policyChecked = append(policyChecked, field)
+		return nil
+	}))
+	err := p.Parse(nil, fileHolder)
+	require.NoError(t, err, "Expected Parse not to return an error")
+
+	assert.Equal(t, []string{"avatar", "document"}, scanned, "The global inspector should have run on every file")
+	assert.Equal(t, []string{"avatar"}, policyChecked, "The named inspector should only have run on the avatar")
+}
+
+func subTestFilesFromMemory(t *testing.T) {
+	t.Parallel()
+
+	type strct struct {
+		File  *formfile.FormFile   `from:"file" json:"file" params:"required"`
+		Files []*formfile.FormFile `from:"file" json:"files" max_items:"2"`
+	}
+
+	holder := formfile.NewMemoryHolder(map[string][]byte{
+		"file": []byte("content of the file"),
+	})
+	holder.Add("files", "a.txt", "text/plain", []byte("a"))
+	holder.Add("files", "b.txt", "text/plain", []byte("b"))
+
+	s := strct{}
+	p := params.New(&s)
+	err := p.Parse(nil, holder)
+	require.NoError(t, err, "Expected Parse not to return an error")
+
+	require.NotNil(t, s.File, "Expected File NOT to be nil")
+	assert.Equal(t, "text/plain; charset=utf-8", s.File.Mime, "Wrong mime type")
+	require.Len(t, s.Files, 2, "Expected 2 files")
+	assert.Equal(t, "b.txt", s.Files[1].Header.Filename, "Wrong filename")
+}
+
+func subTestEmbeddedPointerStruct(t *testing.T) {
+	t.Parallel()
+
+	type Paginator struct {
+		Page *int `from:"query" json:"page" default:"1"`
+	}
+
+	type strct struct {
+		*Paginator
+
+		ID string `from:"url" json:"id"`
+	}
+
+	s := &strct{}
+	sources := map[string]url.Values{
+		"url":   {"id": []string{"42"}},
+		"query": {"page": []string{"24"}},
+	}
+	err := params.New(s).Parse(sources, nil)
+	require.NoError(t, err, "Parse() should not have failed")
+	require.NotNil(t, s.Paginator, "Paginator should have been allocated")
+	require.NotNil(t, s.Page, "Page should have been set")
+	assert.Equal(t, 24, *s.Page, "Wrong page")
+}
+
+func subTestExtractEmbeddedPointer(t *testing.T) {
+	t.Parallel()
+
+	type Paginator struct {
+		Page int `from:"query" json:"page"`
+	}
+
+	type strct struct {
+		*Paginator
+
+		ID string `from:"url" json:"id"`
+	}
+
+	s := &strct{
+		Paginator: &Paginator{Page: 2},
+		ID:        "42",
+	}
+	sources, _ := params.New(s).Extract()
+	assert.Equal(t, "2", sources["query"].Get("page"), "Wrong page")
+	assert.Equal(t, "42", sources["url"].Get("id"), "Wrong id")
+
+	// nil embedded pointers should be skipped
+	s.Paginator = nil
+	sources, _ = params.New(s).Extract()
+	assert.Empty(t, sources["query"], "No query params should have been extracted")
+}
+
+func subTestStrictMode(t *testing.T) {
+	t.Parallel()
+
+	type strictParams struct {
+		PerPage int    `from:"query" json:"per_page" default:"10"`
+		Name    string `from:"form" json:"name"`
+		Ignored string `from:"query" json:"-"`
+	}
+
+	testCases := []struct {
+		description   string
+		strict        bool
+		query         url.Values
+		form          url.Values
+		unknownFields []string
+	}{
+		{
+			"Known params should work",
+			true,
+			url.Values{"per_page": []string{"20"}},
+			url.Values{"name": []string{"name"}},
+			nil,
+		},
+		{
+			"Unknown params should be ignored when not strict",
+			false,
+			url.Values{"perpage": []string{"20"}},
+			url.Values{},
+			nil,
+		},
+		{
+			"Unknown params should be rejected",
+			true,
+			url.Values{"perpage": []string{"20"}, "page": []string{"2"}},
+			url.Values{"nane": []string{"name"}},
+			[]string{"page", "perpage", "nane"},
+		},
+		{
+			"Ignored fields should not consume params",
+			true,
+			url.Values{"Ignored": []string{"value"}},
+			url.Values{},
+			[]string{"Ignored"},
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.description, func(t *testing.T) {
+			t.Parallel()
+
+			s := &strictParams{}
+			p := params.New(s)
+			p.SetStrict(tc.strict)
+			sources := map[string]url.Values{
+				"query":  tc.query,
+				"form":   tc.form,
+				"header": url.Values{"X-Unknown": []string{"value"}},
+			}
+
+			err := p.Parse(sources, nil)
+			if len(tc.unknownFields) == 0 {
+				require.NoError(t, err, "Parse() should have succeed")
+				return
+			}
+
+			require.Error(t, err, "Parse() should have failed")
+			list, ok := err.(perror.List)
+			require.True(t, ok, "the error should be a perror.List")
+			require.Len(t, list, len(tc.unknownFields), "wrong number of errors")
+			for i, field := range tc.unknownFields {
+				assert.Equal(t, field, list[i].Field(), "wrong field")
+				assert.Equal(t, params.ErrMsgUnknownParameter, list[i].Error(), "wrong error message")
+			}
+		})
+	}
+}
+
+func subTestMultipleSources(t *testing.T) {
+	t.Parallel()
+
+	type multiSourcesParams struct {
+		Token  string `from:"header,query" json:"token"`
+		Page   int    `from:"query,form" json:"page" default:"1"`
+		Secret string `from:"query,form" json:"secret" conflict:"error"`
+	}
+
+	testCases := []struct {
+		description    string
+		query          url.Values
+		form           url.Values
+		header         url.Values
+		expectedToken  string
+		expectedPage   int
+		expectedSecret string
+		shouldFail     bool
+	}{
+		{
+			"The first source should be used",
+			url.Values{"token": []string{"query-token"}, "page": []string{"2"}},
+			url.Values{"page": []string{"3"}},
+			url.Values{"token": []string{"header-token"}},
+			"header-token", 2, "", false,
+		},
+		{
+			"The next source should be used as fallback",
+			url.Values{"token": []string{"query-token"}},
+			url.Values{"page": []string{"3"}},
+			url.Values{},
+			"query-token", 3, "", false,
+		},
+		{
+			"The default value should be used if no sources have the param",
+			url.Values{},
+			url.Values{},
+			url.Values{},
+			"", 1, "", false,
+		},
+		{
+			"Identical values should not conflict",
+			url.Values{"secret": []string{"value"}},
+			url.Values{"secret": []string{"value"}},
+			url.Values{},
+			"", 1, "value", false,
+		},
+		{
+			"Different values should conflict",
+			url.Values{"secret": []string{"value"}},
+			url.Values{"secret": []string{"other value"}},
+			url.Values{},
+			"", 1, "", true,
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.description, func(t *testing.T) {
+			t.Parallel()
+
+			s := &multiSourcesParams{}
+			p := params.New(s)
+			p.SetStrict(true)
+			sources := map[string]url.Values{
+				"query":  tc.query,
+				"form":   tc.form,
+				"header": tc.header,
+			}
+
+			err := p.Parse(sources, nil)
+			if tc.shouldFail {
+				require.Error(t, err, "Parse() should have failed")
+				perr, ok := err.(perror.Error)
+				require.True(t, ok, "the error should be a perror")
+				assert.Equal(t, "secret", perr.Field(), "wrong field")
+				assert.Equal(t, params.ErrMsgConflictingValues, perr.Error(), "wrong error message")
+				return
+			}
+
+			require.NoError(t, err, "Parse() should have succeed")
+			assert.Equal(t, tc.expectedToken, s.Token, "wrong token")
+			assert.Equal(t, tc.expectedPage, s.Page, "wrong page")
+			assert.Equal(t, tc.expectedSecret, s.Secret, "wrong secret")
+		})
+	}
+}
+
+func subTestAliases(t *testing.T) {
+	t.Parallel()
+
+	type aliasParams struct {
+		PerPage int    `from:"query,form" json:"per_page" alias:"perPage,page_size" deprecated:"use per_page" default:"10"`
+		Sort    string `from:"query" json:"sort" deprecated:"sorting will be removed"`
+	}
+
+	testCases := []struct {
+		description      string
+		query            url.Values
+		form             url.Values
+		expectedPerPage  int
+		expectedWarnings []params.Warning
+	}{
+		{
+			"Using the name should not warn",
+			url.Values{"per_page": []string{"20"}},
+			url.Values{},
+			20,
+			nil,
+		},
+		{
+			"Using an alias should warn",
+			url.Values{},
+			url.Values{"page_size": []string{"30"}},
+			30,
+			[]params.Warning{
+				{Field: "per_page", Key: "page_size", Message: "use per_page"},
+			},
+		},
+		{
+			"Using a deprecated field should warn",
+			url.Values{"sort": []string{"name"}, "perPage": []string{"40"}},
+			url.Values{},
+			40,
+			[]params.Warning{
+				{Field: "per_page", Key: "perPage", Message: "use per_page"},
+				{Field: "sort", Key: "sort", Message: "sorting will be removed"},
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.description, func(t *testing.T) {
+			t.Parallel()
+
+			s := &aliasParams{}
+			p := params.New(s)
+			p.SetStrict(true)
+			hookWarnings := []params.Warning{}
+			p.SetWarningHook(func(w params.Warning) {
+				hookWarnings = append(hookWarnings, w)
+			})
+			sources := map[string]url.Values{
+				"query": tc.query,
+				"form":  tc.form,
+			}
+
+			err := p.Parse(sources, nil)
+			require.NoError(t, err, "Parse() should have succeed")
+			assert.Equal(t, tc.expectedPerPage, s.PerPage, "wrong per_page")
+			assert.Equal(t, tc.expectedWarnings, p.Warnings(), "wrong warnings")
+			assert.Equal(t, len(tc.expectedWarnings), len(hookWarnings), "the hook should have received all the warnings")
+		})
+	}
+}
+
+type namingStrategyParams struct {
+	UserID  int    `from:"query"`
+	PerPage int    `from:"form"`
+	Sort    string `from:"query" json:"order"`
+}
+
+func subTestNamingStrategy(t *testing.T) {
+	t.Parallel()
+
+	s := &namingStrategyParams{}
+	p := params.New(s)
+	p.SetNamingStrategy(params.CamelCase)
+	p.SetCaseInsensitive(true)
+	p.SetStrict(true)
+	sources := map[string]url.Values{
+		"query": url.Values{"userid": []string{"1"}, "Order": []string{"asc"}},
+		"form":  url.Values{"perPage": []string{"20"}},
+	}
+
+	err := p.Parse(sources, nil)
+	require.NoError(t, err, "Parse() should have succeed")
+	assert.Equal(t, 1, s.UserID, "wrong user id")
+	assert.Equal(t, 20, s.PerPage, "wrong per page")
+	assert.Equal(t, "asc", s.Sort, "wrong sort")
+}
+
+func subTestExtractNamingStrategy(t *testing.T) {
+	t.Parallel()
+
+	s := &namingStrategyParams{UserID: 1, PerPage: 20, Sort: "asc"}
+	p := params.New(s)
+	p.SetNamingStrategy(params.KebabCase)
+	sources, _ := p.Extract()
+
+	assert.Equal(t, "1", sources["query"].Get("user-id"), "wrong user id")
+	assert.Equal(t, "20", sources["form"].Get("per-page"), "wrong per page")
+	assert.Equal(t, "asc", sources["query"].Get("order"), "wrong sort")
+}
+
+type paramTagParams struct {
+	Name     string `from:"query" param:"name" json:"full_name"`
+	Internal string `from:"query" param:"-" json:"internal"`
+	Email    string `from:"query" param:"email,omitempty" json:"-"`
+}
+
+func subTestParamTag(t *testing.T) {
+	t.Parallel()
+
+	s := &paramTagParams{}
+	p := params.New(s)
+	sources := map[string]url.Values{
+		"query": url.Values{
+			"name":      []string{"name"},
+			"full_name": []string{"full name"},
+			"internal":  []string{"internal"},
+			"email":     []string{"email"},
+		},
+	}
+
+	err := p.Parse(sources, nil)
+	require.NoError(t, err, "Parse() should have succeed")
+	assert.Equal(t, "name", s.Name, "the param tag should have the precedence")
+	assert.Empty(t, s.Internal, "the field should have been ignored")
+	assert.Equal(t, "email", s.Email, "the json tag should have been ignored")
+}
+
+func subTestExtractParamTag(t *testing.T) {
+	t.Parallel()
+
+	s := &paramTagParams{Name: "name", Internal: "internal"}
+	sources, _ := params.New(s).Extract()
+
+	query := sources["query"]
+	assert.Equal(t, "name", query.Get("name"), "the param tag should have the precedence")
+	_, found := query["full_name"]
+	assert.False(t, found, "the json name should not be used")
+	_, found = query["internal"]
+	assert.False(t, found, "the field should have been ignored")
+	_, found = query["email"]
+	assert.False(t, found, "omitempty should have been honored")
+}
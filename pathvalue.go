@@ -0,0 +1,21 @@
+//go:build go1.22
+// +build go1.22
+
+package params
+
+import (
+	"net/http"
+	"net/url"
+)
+
+// PathValues builds the url source of a params struct using the path
+// variables matched by the http.ServeMux of Go 1.22+ (r.PathValue()).
+// The names of the variables are the keys of the fields using the url source
+func PathValues(r *http.Request, data interface{}) url.Values {
+	return LookupURLParams(data, r.PathValue)
+}
+
+// requestURLValues returns the url source of a request
+func requestURLValues(r *http.Request, data interface{}) url.Values {
+	return PathValues(r, data)
+}
@@ -0,0 +1,15 @@
+//go:build !go1.22
+// +build !go1.22
+
+package params
+
+import (
+	"net/http"
+	"net/url"
+)
+
+// requestURLValues returns the url source of a request. Path variables
+// are not supported by net/http before Go 1.22
+func requestURLValues(r *http.Request, data interface{}) url.Values {
+	return url.Values{}
+}
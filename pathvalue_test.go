@@ -0,0 +1,61 @@
+//go:build go1.22
+// +build go1.22
+
+// The module targets a version of Go older than 1.22, which makes
+// http.ServeMux use its legacy patterns by default
+//go:debug httpmuxgo121=0
+
+package params_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	params "github.com/Nivl/go-params"
+)
+
+func TestPathValues(t *testing.T) {
+	t.Parallel()
+
+	type strct struct {
+		ID      int    `from:"url" json:"id"`
+		ChildID string `from:"url" json:"child_id"`
+	}
+
+	s := &strct{}
+	var parseErr error
+	mux := http.NewServeMux()
+	mux.HandleFunc("/items/{id}/children/{child_id}", func(w http.ResponseWriter, r *http.Request) {
+		sources := map[string]url.Values{"url": params.PathValues(r, s)}
+		parseErr = params.New(s).Parse(sources, nil)
+	})
+
+	mux.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/items/42/children/abc", nil))
+	require.NoError(t, parseErr, "Parse() should not have failed")
+	assert.Equal(t, 42, s.ID, "Wrong ID")
+	assert.Equal(t, "abc", s.ChildID, "Wrong child ID")
+}
+
+func TestBindRequestPathValues(t *testing.T) {
+	t.Parallel()
+
+	type strct struct {
+		ID int `from:"url" json:"id" params:"required"`
+	}
+
+	var s *strct
+	var bindErr error
+	mux := http.NewServeMux()
+	mux.HandleFunc("/items/{id}", func(w http.ResponseWriter, r *http.Request) {
+		s, bindErr = params.BindRequest[strct](r)
+	})
+
+	mux.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/items/42", nil))
+	require.NoError(t, bindErr, "BindRequest() should not have failed")
+	assert.Equal(t, 42, s.ID, "Wrong ID")
+}
@@ -0,0 +1,25 @@
+package perror
+
+import (
+	"strings"
+)
+
+// List is an Error containing multiple errors
+type List []Error
+
+// Error returns the errors, with their field, separated by a semicolon
+func (l List) Error() string {
+	msgs := make([]string, len(l))
+	for i, err := range l {
+		msgs[i] = err.Field() + ": " + err.Error()
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// Field returns the field name attached to the first error
+func (l List) Field() string {
+	if len(l) == 0 {
+		return ""
+	}
+	return l[0].Field()
+}
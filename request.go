@@ -0,0 +1,212 @@
+package params
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"net/textproto"
+	"net/url"
+	"sort"
+	"strings"
+
+	"github.com/Nivl/go-params/formfile"
+)
+
+// quoteEscaper escapes the quotes and backslashes of a value sent in a
+// Content-Disposition header. Same as the one used by mime/multipart
+var quoteEscaper = strings.NewReplacer("\\", "\\\\", `"`, "\\\"")
+
+// NewRequest creates an http.Request from a params struct.
+// The url params are used to fill the placeholders of urlTemplate
+// (/items/{id}), the query params are added to the query string, the header
+// params are set as headers, and the form params and the files are sent in
+// the body. The body is encoded using multipart/form-data if the struct
+// contains files, or application/x-www-form-urlencoded otherwise
+func NewRequest(method, urlTemplate string, data interface{}) (*http.Request, error) {
+	extracted, err := New(data).extract()
+	if err != nil {
+		return nil, err
+	}
+
+	for sourceType, values := range extracted.sources {
+		switch sourceType {
+		case "url", "query", "form", "header":
+		default:
+			if len(values) > 0 {
+				return nil, fmt.Errorf("source %s cannot be used in a request", sourceType)
+			}
+		}
+	}
+
+	// We build the URL
+	rawURL, err := renderPathTemplate(urlTemplate, extracted.sources["url"])
+	if err != nil {
+		return nil, err
+	}
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return nil, err
+	}
+	if len(extracted.sources["query"]) > 0 {
+		query := u.Query()
+		for key, values := range extracted.sources["query"] {
+			for _, v := range values {
+				query.Add(key, v)
+			}
+		}
+		u.RawQuery = query.Encode()
+	}
+
+	// We build the body
+	var body io.Reader
+	contentType := ""
+	if len(extracted.files) > 0 || len(extracted.multipleFiles) > 0 {
+		buf := &bytes.Buffer{}
+		if contentType, err = writeMultipartBody(buf, extracted); err != nil {
+			return nil, err
+		}
+		body = buf
+	} else if len(extracted.sources["form"]) > 0 {
+		body = strings.NewReader(extracted.sources["form"].Encode())
+		contentType = "application/x-www-form-urlencoded"
+	}
+
+	req, err := http.NewRequest(method, u.String(), body)
+	if err != nil {
+		return nil, err
+	}
+	if contentType != "" {
+		req.Header.Set("Content-Type", contentType)
+	}
+	for key, values := range extracted.sources["header"] {
+		for _, v := range values {
+			req.Header.Add(key, v)
+		}
+	}
+	return req, nil
+}
+
+// renderPathTemplate replaces all the placeholders of a path template
+// (/items/{id}) by their value
+func renderPathTemplate(template string, values url.Values) (string, error) {
+	var out strings.Builder
+	for {
+		start := strings.Index(template, "{")
+		if start == -1 {
+			break
+		}
+		end := strings.Index(template[start:], "}")
+		if end == -1 {
+			return "", fmt.Errorf("unclosed placeholder in %s", template)
+		}
+		end += start
+
+		name := template[start+1 : end]
+		value := values.Get(name)
+		if value == "" {
+			return "", fmt.Errorf("no value provided for the url param %s", name)
+		}
+
+		out.WriteString(template[:start])
+		out.WriteString(url.PathEscape(value))
+		template = template[end+1:]
+	}
+	out.WriteString(template)
+	return out.String(), nil
+}
+
+// writeMultipartBody writes the form params and the files in w using the
+// multipart/form-data format, and returns the content type to use
+func writeMultipartBody(w io.Writer, data *extractedData) (contentType string, err error) {
+	mw := multipart.NewWriter(w)
+
+	form := data.sources["form"]
+	for _, key := range sortedKeys(form) {
+		for _, v := range form[key] {
+			if err := mw.WriteField(key, v); err != nil {
+				return "", err
+			}
+		}
+	}
+
+	fileKeys := make([]string, 0, len(data.files))
+	for key := range data.files {
+		fileKeys = append(fileKeys, key)
+	}
+	sort.Strings(fileKeys)
+	for _, key := range fileKeys {
+		if err := writeMultipartFile(mw, key, data.files[key]); err != nil {
+			return "", err
+		}
+	}
+
+	multipleFileKeys := make([]string, 0, len(data.multipleFiles))
+	for key := range data.multipleFiles {
+		multipleFileKeys = append(multipleFileKeys, key)
+	}
+	sort.Strings(multipleFileKeys)
+	for _, key := range multipleFileKeys {
+		for _, ff := range data.multipleFiles[key] {
+			if err := writeMultipartFile(mw, key, ff); err != nil {
+				return "", err
+			}
+		}
+	}
+
+	if err := mw.Close(); err != nil {
+		return "", err
+	}
+	return mw.FormDataContentType(), nil
+}
+
+// writeMultipartFile writes a file in a multipart body. The file is
+// rewound before and after being read
+func writeMultipartFile(mw *multipart.Writer, key string, ff *formfile.FormFile) error {
+	if ff == nil || ff.File == nil {
+		return nil
+	}
+
+	filename := key
+	contentType := ff.Mime
+	if ff.Header != nil {
+		if ff.Header.Filename != "" {
+			filename = ff.Header.Filename
+		}
+		if declared := ff.Header.Header.Get("Content-Type"); declared != "" {
+			contentType = declared
+		}
+	}
+	if contentType == "" {
+		contentType = "application/octet-stream"
+	}
+
+	h := textproto.MIMEHeader{}
+	h.Set("Content-Disposition", fmt.Sprintf(`form-data; name="%s"; filename="%s"`,
+		quoteEscaper.Replace(key), quoteEscaper.Replace(filename)))
+	h.Set("Content-Type", contentType)
+	part, err := mw.CreatePart(h)
+	if err != nil {
+		return err
+	}
+
+	if _, err := ff.File.Seek(0, io.SeekStart); err != nil {
+		return err
+	}
+	if _, err := io.Copy(part, ff.File); err != nil {
+		return err
+	}
+	_, err = ff.File.Seek(0, io.SeekStart)
+	return err
+}
+
+// sortedKeys returns the keys of the provided values, sorted
+func sortedKeys(values url.Values) []string {
+	keys := make([]string, 0, len(values))
+	for key := range values {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
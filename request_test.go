@@ -0,0 +1,110 @@
+package params_test
+
+import (
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	params "github.com/Nivl/go-params"
+	"github.com/Nivl/go-params/formfile"
+)
+
+func TestNewRequest(t *testing.T) {
+	t.Run("url, query, headers and urlencoded body", subTestNewRequestURLEncoded)
+	t.Run("multipart body", subTestNewRequestMultipart)
+	t.Run("missing url param", subTestNewRequestMissingURLParam)
+}
+
+func subTestNewRequestURLEncoded(t *testing.T) {
+	t.Parallel()
+
+	type strct struct {
+		ID      string   `from:"url" json:"id"`
+		ChildID string   `from:"url" json:"child_id"`
+		Page    int      `from:"query" json:"page"`
+		Token   string   `from:"header" json:"X-Token"`
+		Name    string   `from:"form" json:"name"`
+		Tags    []string `from:"form" json:"tags"`
+	}
+
+	s := &strct{
+		ID:      "item 1",
+		ChildID: "2",
+		Page:    3,
+		Token:   "secret",
+		Name:    "name",
+		Tags:    []string{"a", "b"},
+	}
+	req, err := params.NewRequest(http.MethodPost, "https://example.com/items/{id}/children/{child_id}?sort=asc", s)
+	require.NoError(t, err, "NewRequest() should not have failed")
+
+	assert.Equal(t, http.MethodPost, req.Method, "Wrong method")
+	assert.Equal(t, "/items/item 1/children/2", req.URL.Path, "Wrong path")
+	assert.Equal(t, "/items/item%201/children/2", req.URL.EscapedPath(), "Wrong escaped path")
+	assert.Equal(t, url.Values{"page": []string{"3"}, "sort": []string{"asc"}}, req.URL.Query(), "Wrong query")
+	assert.Equal(t, "secret", req.Header.Get("X-Token"), "Wrong header")
+	assert.Equal(t, "application/x-www-form-urlencoded", req.Header.Get("Content-Type"), "Wrong content type")
+
+	require.NoError(t, req.ParseForm(), "ParseForm() should not have failed")
+	assert.Equal(t, url.Values{"name": []string{"name"}, "tags": []string{"a", "b"}}, req.PostForm, "Wrong body")
+}
+
+func subTestNewRequestMultipart(t *testing.T) {
+	t.Parallel()
+
+	type strct struct {
+		Name    string               `from:"form" json:"name"`
+		Avatar  *formfile.FormFile   `from:"file" json:"avatar"`
+		Gallery []*formfile.FormFile `from:"file" json:"gallery"`
+	}
+
+	// We use params to build the files
+	holder := formfile.NewMemoryHolder(map[string][]byte{
+		"avatar": []byte("avatar content"),
+	})
+	holder.Add("gallery", "1.txt", "text/plain", []byte("first"))
+	holder.Add("gallery", "2.txt", "text/plain", []byte("second"))
+	s := &strct{}
+	sources := map[string]url.Values{"form": {}}
+	require.NoError(t, params.New(s).Parse(sources, holder), "Parse() should not have failed")
+	s.Name = "name"
+
+	req, err := params.NewRequest(http.MethodPut, "/users", s)
+	require.NoError(t, err, "NewRequest() should not have failed")
+	require.NoError(t, req.ParseMultipartForm(1<<20), "ParseMultipartForm() should not have failed")
+
+	assert.Equal(t, []string{"name"}, req.MultipartForm.Value["name"], "Wrong form value")
+
+	// We parse the request back to make sure everything is there
+	parsed := &strct{}
+	sources = map[string]url.Values{"form": req.MultipartForm.Value}
+	err = params.New(parsed).Parse(sources, formfile.NewRequestHolder(req))
+	require.NoError(t, err, "Parse() should not have failed")
+
+	assert.Equal(t, "name", parsed.Name, "Wrong name")
+	require.NotNil(t, parsed.Avatar, "Avatar should not be nil")
+	assert.Equal(t, "avatar", parsed.Avatar.Header.Filename, "Wrong filename")
+	content, err := ioutil.ReadAll(parsed.Avatar.File)
+	require.NoError(t, err, "ReadAll() should not have failed")
+	assert.Equal(t, "avatar content", string(content), "Wrong content")
+
+	require.Len(t, parsed.Gallery, 2, "Wrong number of files")
+	assert.Equal(t, "2.txt", parsed.Gallery[1].Header.Filename, "Wrong filename")
+	assert.Equal(t, "text/plain", parsed.Gallery[1].Header.Header.Get("Content-Type"), "Wrong content type")
+}
+
+func subTestNewRequestMissingURLParam(t *testing.T) {
+	t.Parallel()
+
+	type strct struct {
+		ID string `from:"url" json:"id"`
+	}
+
+	_, err := params.NewRequest(http.MethodGet, "/items/{id}/children/{child_id}", &strct{ID: "1"})
+	require.Error(t, err, "NewRequest() should have failed")
+	assert.Contains(t, err.Error(), "child_id", "NewRequest() failed with an unexpected error")
+}
@@ -0,0 +1,236 @@
+package params_test
+
+import (
+	"errors"
+	"fmt"
+	"math/rand"
+	"net/url"
+	"reflect"
+	"testing"
+	"testing/quick"
+	"time"
+
+	params "github.com/Nivl/go-params"
+	"github.com/Nivl/go-params/formfile"
+	"github.com/Nivl/go-types/date"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type (
+	roundTripColor string
+	roundTripCount int
+	roundTripFlag  bool
+)
+
+// roundTripPoint is a Scanner and a Formatter
+type roundTripPoint struct {
+	X, Y int
+}
+
+func (p *roundTripPoint) ScanString(value string) error {
+	if _, err := fmt.Sscanf(value, "%d:%d", &p.X, &p.Y); err != nil {
+		return errors.New("invalid point")
+	}
+	return nil
+}
+
+func (p *roundTripPoint) FormatString() string {
+	return fmt.Sprintf("%d:%d", p.X, p.Y)
+}
+
+type RoundTripEmbedded struct {
+	Embedded string `from:"query" json:"embedded"`
+}
+
+type roundTripParams struct {
+	RoundTripEmbedded
+
+	String     string    `from:"query" json:"string"`
+	Int        int       `from:"query" json:"int"`
+	Bool       bool      `from:"query" json:"bool"`
+	StringPtr  *string   `from:"query" json:"string_ptr"`
+	IntPtr     *int      `from:"query" json:"int_ptr"`
+	BoolPtr    *bool     `from:"query" json:"bool_ptr"`
+	Strings    []string  `from:"query" json:"strings"`
+	Ints       []int     `from:"query" json:"ints"`
+	Bools      []bool    `from:"query" json:"bools"`
+	StringPtrs []*string `from:"query" json:"string_ptrs"`
+	IntPtrs    []*int    `from:"query" json:"int_ptrs"`
+	BoolPtrs   []*bool   `from:"query" json:"bool_ptrs"`
+
+	Color     roundTripColor    `from:"query" json:"color"`
+	Count     roundTripCount    `from:"query" json:"count"`
+	Flag      roundTripFlag     `from:"query" json:"flag"`
+	Colors    []roundTripColor  `from:"query" json:"colors"`
+	ColorPtrs []*roundTripColor `from:"query" json:"color_ptrs"`
+	Counts    []roundTripCount  `from:"query" json:"counts"`
+
+	Date      date.Date         `from:"query" json:"date"`
+	DatePtr   *date.Date        `from:"query" json:"date_ptr"`
+	Dates     []date.Date       `from:"query" json:"dates"`
+	DatePtrs  []*date.Date      `from:"query" json:"date_ptrs"`
+	Point     roundTripPoint    `from:"query" json:"point"`
+	PointPtr  *roundTripPoint   `from:"query" json:"point_ptr"`
+	Points    []roundTripPoint  `from:"query" json:"points"`
+	PointPtrs []*roundTripPoint `from:"query" json:"point_ptrs"`
+	Time      time.Time         `from:"query" json:"time"`
+	TimePtr   *time.Time        `from:"query" json:"time_ptr"`
+	Times     []time.Time       `from:"query" json:"times"`
+	TimePtrs  []*time.Time      `from:"query" json:"time_ptrs"`
+
+	Header   string `from:"header" json:"X-Header"`
+	Form     string `from:"form,query" json:"form"`
+	Optional int    `from:"query" json:"optional,omitempty" default:"5"`
+
+	DefaultString  string   `from:"query" json:"default_string" default:"foo"`
+	DefaultStrings []string `from:"query" json:"default_strings" default:"a,b"`
+}
+
+// Generate implements the quick.Generator interface. Only the values
+// that can be sent in a request are generated
+func (roundTripParams) Generate(r *rand.Rand, size int) reflect.Value {
+	p := roundTripParams{}
+	v := reflect.ValueOf(&p).Elem()
+	for i := 0; i < v.NumField(); i++ {
+		setRandomValue(r, v.Field(i))
+		// Parse replaces the empty strings and slices by the default
+		// value, so they cannot round-trip
+		if v.Type().Field(i).Tag.Get("default") == "" {
+			continue
+		}
+		for (v.Field(i).Kind() == reflect.String || v.Field(i).Kind() == reflect.Slice) && v.Field(i).Len() == 0 {
+			setRandomValue(r, v.Field(i))
+		}
+	}
+	return v
+}
+
+// setRandomValue sets a random value to v. The pointers and the slices
+// are sometimes left nil, and the slices are sometimes empty
+func setRandomValue(r *rand.Rand, v reflect.Value) {
+	switch {
+	case v.Type() == reflect.TypeOf(time.Time{}):
+		// The times use random offsets, and are normalized to UTC
+		// by Parse
+		loc := time.FixedZone("", (r.Intn(105)-48)*15*60)
+		v.Set(reflect.ValueOf(time.Unix(r.Int63n(1e10), r.Int63n(1e9)).In(loc)))
+	case v.Type() == reflect.TypeOf(date.Date{}):
+		t := time.Date(1970+r.Intn(200), time.Month(1+r.Intn(12)), 1+r.Intn(28), 0, 0, 0, 0, time.UTC)
+		v.Set(reflect.ValueOf(date.Date{Time: t}))
+	case v.Kind() == reflect.Ptr:
+		if r.Intn(4) == 0 {
+			return
+		}
+		v.Set(reflect.New(v.Type().Elem()))
+		setRandomValue(r, v.Elem())
+	case v.Kind() == reflect.Slice:
+		switch r.Intn(4) {
+		case 0:
+			return
+		case 1:
+			v.Set(reflect.MakeSlice(v.Type(), 0, 0))
+			return
+		}
+		v.Set(reflect.MakeSlice(v.Type(), 1+r.Intn(4), 4))
+		for i := 0; i < v.Len(); i++ {
+			// a nil item cannot be sent
+			item := v.Index(i)
+			if item.Kind() == reflect.Ptr {
+				item.Set(reflect.New(item.Type().Elem()))
+				item = item.Elem()
+			}
+			setRandomValue(r, item)
+		}
+	case v.Kind() == reflect.Struct:
+		for i := 0; i < v.NumField(); i++ {
+			setRandomValue(r, v.Field(i))
+		}
+	case v.Kind() == reflect.String:
+		runes := []rune("abc XYZ,;-_é/?&=%")
+		s := make([]rune, r.Intn(8))
+		for i := range s {
+			s[i] = runes[r.Intn(len(runes))]
+		}
+		v.SetString(string(s))
+	case v.Kind() == reflect.Int:
+		v.SetInt(r.Int63n(2000) - 1000)
+	case v.Kind() == reflect.Bool:
+		v.SetBool(r.Intn(2) == 0)
+	}
+}
+
+// normalizeTimes converts all the times to UTC, as done by Parse
+func (p *roundTripParams) normalizeTimes() {
+	p.Time = p.Time.UTC()
+	if p.TimePtr != nil {
+		*p.TimePtr = p.TimePtr.UTC()
+	}
+	for i := range p.Times {
+		p.Times[i] = p.Times[i].UTC()
+	}
+	for _, t := range p.TimePtrs {
+		*t = t.UTC()
+	}
+}
+
+func TestExtractParseRoundTrip(t *testing.T) {
+	t.Parallel()
+
+	roundTrip := func(data roundTripParams) bool {
+		sources, _ := params.New(&data).Extract()
+		// The sources of the nil fields are not extracted
+		for _, source := range []string{"query", "form", "header"} {
+			if _, found := sources[source]; !found {
+				sources[source] = url.Values{}
+			}
+		}
+
+		parsed := roundTripParams{}
+		err := params.New(&parsed).Parse(sources, formfile.NewMemoryHolder(nil))
+		data.normalizeTimes()
+		return assert.NoError(t, err, "Parse() should have succeed") &&
+			assert.Equal(t, data, parsed, "Parse(Extract(x)) should be equal to x")
+	}
+
+	config := &quick.Config{
+		MaxCount: 500,
+		Rand:     rand.New(rand.NewSource(42)),
+	}
+	assert.NoError(t, quick.Check(roundTrip, config), "the data should have round-tripped")
+}
+
+func TestExtractFormatting(t *testing.T) {
+	t.Parallel()
+
+	type strct struct {
+		Name     string            `from:"query" json:"name" params:"trim"`
+		Names    []string          `from:"query" json:"names" params:"trim"`
+		Point    roundTripPoint    `from:"query" json:"point"`
+		Points   []*roundTripPoint `from:"query" json:"points"`
+		Time     time.Time         `from:"query" json:"time"`
+		Optional int               `from:"query" json:"optional,omitempty" default:"5"`
+		Empty    int               `from:"query" json:"empty,omitempty"`
+	}
+
+	data := &strct{
+		Name:   " name ",
+		Names:  []string{" a", "b "},
+		Point:  roundTripPoint{X: 1, Y: -2},
+		Points: []*roundTripPoint{{X: 3, Y: 4}},
+		Time:   time.Date(2020, 1, 2, 5, 4, 5, 6, time.FixedZone("", 2*60*60)),
+	}
+	sources, files := params.New(data).Extract()
+	require.NotNil(t, sources["query"], "the query should have been extracted")
+	assert.Empty(t, files, "Extract() should not return files")
+
+	query := sources["query"]
+	assert.Equal(t, "name", query.Get("name"), "the transformations should have been applied")
+	assert.Equal(t, []string{"a", "b"}, query["names"], "the transformations should have been applied to the items")
+	assert.Equal(t, "1:-2", query.Get("point"), "the Formatter should have been used")
+	assert.Equal(t, []string{"3:4"}, query["points"], "the pointers should have been dereferenced")
+	assert.Equal(t, "2020-01-02T03:04:05.000000006Z", query.Get("time"), "the time should use RFC 3339, in UTC")
+	assert.Equal(t, []string{"0"}, query["optional"], "the zero values should be kept when a default value is set")
+	_, found := query["empty"]
+	assert.False(t, found, "the zero values should be omitted")
+}
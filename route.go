@@ -0,0 +1,104 @@
+package params
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+// Route is a path template (/items/{id}/children/{childID}) used to extract
+// the url params from a path, or to render a path from url params.
+// A placeholder needs to cover a whole segment of the path
+type Route struct {
+	pattern  string
+	segments []routeSegment
+}
+
+// routeSegment represents a segment of a route
+type routeSegment struct {
+	// value contains the name of the param for placeholders, or the
+	// expected value for the other segments
+	value         string
+	isPlaceholder bool
+}
+
+// NewRoute creates a new Route from a pattern
+func NewRoute(pattern string) (*Route, error) {
+	parts := strings.Split(pattern, "/")
+	r := &Route{
+		pattern:  pattern,
+		segments: make([]routeSegment, len(parts)),
+	}
+
+	names := map[string]bool{}
+	for i, part := range parts {
+		if !strings.ContainsAny(part, "{}") {
+			r.segments[i] = routeSegment{value: part}
+			continue
+		}
+
+		if !strings.HasPrefix(part, "{") || !strings.HasSuffix(part, "}") || strings.Count(part, "{") != 1 {
+			return nil, fmt.Errorf("invalid segment %s in %s: a placeholder needs to cover a whole segment", part, pattern)
+		}
+		name := part[1 : len(part)-1]
+		if name == "" {
+			return nil, fmt.Errorf("empty placeholder in %s", pattern)
+		}
+		if names[name] {
+			return nil, fmt.Errorf("placeholder %s is used multiple times in %s", name, pattern)
+		}
+		names[name] = true
+		r.segments[i] = routeSegment{value: name, isPlaceholder: true}
+	}
+	return r, nil
+}
+
+// String returns the pattern of the route
+func (r *Route) String() string {
+	return r.pattern
+}
+
+// Match extracts the url params from the given path. The path is expected
+// to be escaped (as returned by url.URL.EscapedPath()) so an escaped slash
+// can be part of a param.
+// Returns false if the path doesn't match the route
+func (r *Route) Match(path string) (url.Values, bool) {
+	parts := strings.Split(path, "/")
+	if len(parts) != len(r.segments) {
+		return nil, false
+	}
+
+	values := url.Values{}
+	for i, segment := range r.segments {
+		part, err := url.PathUnescape(parts[i])
+		if err != nil {
+			return nil, false
+		}
+
+		if !segment.isPlaceholder {
+			if part != segment.value {
+				return nil, false
+			}
+			continue
+		}
+
+		if part == "" {
+			return nil, false
+		}
+		values.Set(segment.value, part)
+	}
+	return values, true
+}
+
+// MatchRequest extracts the url params from the path of the request.
+// Returns false if the path doesn't match the route
+func (r *Route) MatchRequest(req *http.Request) (url.Values, bool) {
+	return r.Match(req.URL.EscapedPath())
+}
+
+// Render renders a path using the provided url params, like the url
+// source returned by Params.Extract()
+func (r *Route) Render(values url.Values) (string, error) {
+	return renderPathTemplate(r.pattern, values)
+}
@@ -0,0 +1,101 @@
+package params_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	params "github.com/Nivl/go-params"
+)
+
+func TestNewRouteError(t *testing.T) {
+	testCases := []struct {
+		description string
+		pattern     string
+	}{
+		{"partial placeholder", "/items/{id}.json"},
+		{"unclosed placeholder", "/items/{id"},
+		{"empty placeholder", "/items/{}"},
+		{"duplicate placeholder", "/items/{id}/children/{id}"},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.description, func(t *testing.T) {
+			t.Parallel()
+			_, err := params.NewRoute(tc.pattern)
+			require.Error(t, err, "NewRoute() should have failed")
+		})
+	}
+}
+
+func TestRouteMatch(t *testing.T) {
+	route, err := params.NewRoute("/items/{id}/children/{childID}")
+	require.NoError(t, err, "NewRoute() should not have failed")
+
+	testCases := []struct {
+		description    string
+		path           string
+		expectedMatch  bool
+		expectedValues url.Values
+	}{
+		{
+			"valid path should match",
+			"/items/42/children/abc",
+			true,
+			url.Values{"id": []string{"42"}, "childID": []string{"abc"}},
+		},
+		{
+			"escaped values should be unescaped",
+			"/items/a%2Fb/children/c%20d",
+			true,
+			url.Values{"id": []string{"a/b"}, "childID": []string{"c d"}},
+		},
+		{"different literal should not match", "/item/42/children/abc", false, nil},
+		{"too many segments should not match", "/items/42/children/abc/def", false, nil},
+		{"not enough segments should not match", "/items/42", false, nil},
+		{"empty param should not match", "/items//children/abc", false, nil},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.description, func(t *testing.T) {
+			t.Parallel()
+			values, match := route.Match(tc.path)
+			assert.Equal(t, tc.expectedMatch, match, "Match() returned an unexpected result")
+			assert.Equal(t, tc.expectedValues, values, "Match() returned unexpected values")
+		})
+	}
+}
+
+func TestRouteMatchRequest(t *testing.T) {
+	t.Parallel()
+
+	type strct struct {
+		ID      int    `from:"url" json:"id"`
+		ChildID string `from:"url" json:"childID"`
+	}
+
+	route, err := params.NewRoute("/items/{id}/children/{childID}")
+	require.NoError(t, err, "NewRoute() should not have failed")
+
+	req := httptest.NewRequest(http.MethodGet, "/items/42/children/a%2Fb", nil)
+	values, match := route.MatchRequest(req)
+	require.True(t, match, "MatchRequest() should have matched")
+
+	s := &strct{}
+	err = params.New(s).Parse(map[string]url.Values{"url": values}, nil)
+	require.NoError(t, err, "Parse() should not have failed")
+	assert.Equal(t, 42, s.ID, "Wrong ID")
+	assert.Equal(t, "a/b", s.ChildID, "Wrong childID")
+
+	// We render the path back using the extracted data
+	sources, _ := params.New(s).Extract()
+	path, err := route.Render(sources["url"])
+	require.NoError(t, err, "Render() should not have failed")
+	assert.Equal(t, "/items/42/children/a%2Fb", path, "Render() returned an unexpected path")
+}
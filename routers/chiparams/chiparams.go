@@ -0,0 +1,29 @@
+// Package chiparams converts the URL params of go-chi/chi into the url
+// source expected by params.Parse()
+package chiparams
+
+import (
+	"net/http"
+	"net/url"
+
+	"github.com/go-chi/chi/v5"
+)
+
+// URLValues returns the URL params of the request as url.Values
+func URLValues(r *http.Request) url.Values {
+	values := url.Values{}
+	rctx := chi.RouteContext(r.Context())
+	if rctx == nil {
+		return values
+	}
+
+	for i, key := range rctx.URLParams.Keys {
+		// wildcards are stored with the "*" key, which cannot be a
+		// param name
+		if key == "*" {
+			continue
+		}
+		values.Set(key, rctx.URLParams.Values[i])
+	}
+	return values
+}
@@ -0,0 +1,37 @@
+package chiparams_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/Nivl/go-params/routers/chiparams"
+)
+
+func TestURLValues(t *testing.T) {
+	t.Parallel()
+
+	var values url.Values
+	router := chi.NewRouter()
+	router.Get("/items/{id}/children/{child_id}/*", func(w http.ResponseWriter, r *http.Request) {
+		values = chiparams.URLValues(r)
+	})
+
+	router.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/items/42/children/abc/rest", nil))
+	expected := url.Values{
+		"id":       []string{"42"},
+		"child_id": []string{"abc"},
+	}
+	assert.Equal(t, expected, values, "URLValues() returned unexpected values")
+}
+
+func TestURLValuesNoRouter(t *testing.T) {
+	t.Parallel()
+
+	values := chiparams.URLValues(httptest.NewRequest(http.MethodGet, "/", nil))
+	assert.Empty(t, values, "URLValues() should have returned no values")
+}
@@ -0,0 +1,13 @@
+module github.com/Nivl/go-params/routers/httprouterparams
+
+go 1.18
+
+require (
+	github.com/julienschmidt/httprouter v1.3.0
+	github.com/stretchr/testify v1.3.0
+)
+
+require (
+	github.com/davecgh/go-spew v1.1.0 // indirect
+	github.com/pmezard/go-difflib v1.0.0 // indirect
+)
@@ -0,0 +1,21 @@
+// Package httprouterparams converts the params of julienschmidt/httprouter
+// into the url source expected by params.Parse()
+package httprouterparams
+
+import (
+	"net/url"
+	"strings"
+
+	"github.com/julienschmidt/httprouter"
+)
+
+// URLValues returns the params of the route as url.Values
+func URLValues(ps httprouter.Params) url.Values {
+	values := make(url.Values, len(ps))
+	for _, p := range ps {
+		// catch-all params (*name) always start with a slash
+		// that is not part of the value
+		values.Set(p.Key, strings.TrimPrefix(p.Value, "/"))
+	}
+	return values
+}
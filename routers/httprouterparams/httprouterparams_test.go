@@ -0,0 +1,31 @@
+package httprouterparams_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/julienschmidt/httprouter"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/Nivl/go-params/routers/httprouterparams"
+)
+
+func TestURLValues(t *testing.T) {
+	t.Parallel()
+
+	var values url.Values
+	router := httprouter.New()
+	router.GET("/items/:id/children/:child_id/*path", func(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
+		values = httprouterparams.URLValues(ps)
+	})
+
+	router.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/items/42/children/abc/some/file", nil))
+	expected := url.Values{
+		"id":       []string{"42"},
+		"child_id": []string{"abc"},
+		"path":     []string{"some/file"},
+	}
+	assert.Equal(t, expected, values, "URLValues() returned unexpected values")
+}
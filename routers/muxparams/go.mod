@@ -0,0 +1,13 @@
+module github.com/Nivl/go-params/routers/muxparams
+
+go 1.18
+
+require (
+	github.com/gorilla/mux v1.8.1
+	github.com/stretchr/testify v1.3.0
+)
+
+require (
+	github.com/davecgh/go-spew v1.1.0 // indirect
+	github.com/pmezard/go-difflib v1.0.0 // indirect
+)
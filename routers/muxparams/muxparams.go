@@ -0,0 +1,20 @@
+// Package muxparams converts the path variables of gorilla/mux into the url
+// source expected by params.Parse()
+package muxparams
+
+import (
+	"net/http"
+	"net/url"
+
+	"github.com/gorilla/mux"
+)
+
+// URLValues returns the path variables of the request as url.Values
+func URLValues(r *http.Request) url.Values {
+	vars := mux.Vars(r)
+	values := make(url.Values, len(vars))
+	for key, value := range vars {
+		values.Set(key, value)
+	}
+	return values
+}
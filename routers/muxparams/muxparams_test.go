@@ -0,0 +1,30 @@
+package muxparams_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/Nivl/go-params/routers/muxparams"
+)
+
+func TestURLValues(t *testing.T) {
+	t.Parallel()
+
+	var values url.Values
+	router := mux.NewRouter()
+	router.HandleFunc("/items/{id}/children/{child_id}", func(w http.ResponseWriter, r *http.Request) {
+		values = muxparams.URLValues(r)
+	})
+
+	router.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/items/42/children/abc", nil))
+	expected := url.Values{
+		"id":       []string{"42"},
+		"child_id": []string{"abc"},
+	}
+	assert.Equal(t, expected, values, "URLValues() returned unexpected values")
+}
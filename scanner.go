@@ -4,3 +4,9 @@ package params
 type Scanner interface {
 	ScanString(date string) error
 }
+
+// Formatter is the counterpart of Scanner. It's used by Extract to convert
+// a custom type to a string that can be read by ScanString
+type Formatter interface {
+	FormatString() string
+}
@@ -0,0 +1,147 @@
+package params
+
+import (
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+const (
+	// slugPattern is the pattern matched by a valid slug
+	slugPattern = `^[a-z0-9]([a-z0-9_-]*[a-z0-9])?$`
+
+	// uuidPattern is the pattern matched by a valid UUIDv4
+	uuidPattern = `^[a-f0-9]{8}-[a-f0-9]{4}-4[a-f0-9]{3}-[89abAB][a-f0-9]{3}-[a-f0-9]{12}$`
+)
+
+// Schema represents the schema of a param. It contains the subset of JSON
+// Schema shared with the OpenAPI 3 schema object
+type Schema struct {
+	// SchemaURI contains the JSON Schema version. Only set on the root
+	// schema of a JSON Schema document
+	SchemaURI string `json:"$schema,omitempty"`
+
+	Type        string             `json:"type,omitempty"`
+	Format      string             `json:"format,omitempty"`
+	Pattern     string             `json:"pattern,omitempty"`
+	Description string             `json:"description,omitempty"`
+	Enum        []interface{}      `json:"enum,omitempty"`
+	Default     interface{}        `json:"default,omitempty"`
+	Minimum     *int               `json:"minimum,omitempty"`
+	Maximum     *int               `json:"maximum,omitempty"`
+	MinLength   *int               `json:"minLength,omitempty"`
+	MaxLength   *int               `json:"maxLength,omitempty"`
+	Items       *Schema            `json:"items,omitempty"`
+	MinItems    *int               `json:"minItems,omitempty"`
+	MaxItems    *int               `json:"maxItems,omitempty"`
+	Properties  map[string]*Schema `json:"properties,omitempty"`
+	Required    []string           `json:"required,omitempty"`
+	Deprecated  bool               `json:"deprecated,omitempty"`
+}
+
+// newFieldSchema returns the schema of a param
+func newFieldSchema(field *FieldInfo) *Schema {
+	opts := field.Options
+	fieldType := field.Type
+	if fieldType.Kind() == reflect.Ptr && !isFileType(fieldType) {
+		fieldType = fieldType.Elem()
+	}
+
+	if fieldType.Kind() == reflect.Slice {
+		schema := &Schema{
+			Type:     "array",
+			Items:    newValueSchema(fieldType.Elem(), opts, ""),
+			MinItems: opts.MinItems,
+			MaxItems: opts.MaxItems,
+		}
+		if field.Default != "" {
+			defaults := []interface{}{}
+			for _, v := range strings.Split(field.Default, ",") {
+				defaults = append(defaults, typedValue(schema.Items.Type, v))
+			}
+			schema.Default = defaults
+		}
+		return schema
+	}
+	return newValueSchema(fieldType, opts, field.Default)
+}
+
+// keySchema returns the schema to use for one of the keys of a param.
+// A copy of the schema flagged as deprecated is returned if the key
+// is deprecated
+func keySchema(field *FieldInfo, fieldSchema *Schema, key string) *Schema {
+	if !field.Options.IsDeprecatedKey(key) {
+		return fieldSchema
+	}
+	deprecatedSchema := *fieldSchema
+	deprecatedSchema.Deprecated = true
+	deprecatedSchema.Description = field.Options.Deprecated
+	return &deprecatedSchema
+}
+
+// newValueSchema returns the schema of a single value
+func newValueSchema(valueType reflect.Type, opts *Options, defaultValue string) *Schema {
+	if valueType.String() == "*formfile.FormFile" {
+		return &Schema{Type: "string", Format: "binary"}
+	}
+	if valueType.Kind() == reflect.Ptr {
+		valueType = valueType.Elem()
+	}
+
+	schema := &Schema{}
+	switch valueType.Kind() {
+	case reflect.Bool:
+		schema.Type = "boolean"
+	case reflect.Int:
+		schema.Type = "integer"
+		schema.Minimum = opts.MinInt
+		schema.Maximum = opts.MaxInt
+	default:
+		schema.Type = "string"
+		if opts.MaxLen > 0 {
+			maxLen := opts.MaxLen
+			schema.MaxLength = &maxLen
+		}
+		if opts.NoEmpty {
+			minLen := 1
+			schema.MinLength = &minLen
+		}
+		switch {
+		case opts.ValidateUUID:
+			schema.Format = "uuid"
+			schema.Pattern = uuidPattern
+		case opts.ValidateEmail:
+			schema.Format = "email"
+		case opts.ValidateURL:
+			schema.Format = "uri"
+		case opts.ValidateSlug:
+			schema.Pattern = slugPattern
+		case opts.ValidateSlugOrUUID:
+			schema.Pattern = "(" + slugPattern + ")|(" + uuidPattern + ")"
+		}
+	}
+
+	for _, v := range opts.AuthorizedValues {
+		schema.Enum = append(schema.Enum, typedValue(schema.Type, v))
+	}
+	if defaultValue != "" {
+		schema.Default = typedValue(schema.Type, defaultValue)
+	}
+	return schema
+}
+
+// typedValue converts a value from a tag to the provided schema type.
+// The value is returned as is if it cannot be converted
+func typedValue(schemaType, value string) interface{} {
+	switch schemaType {
+	case "boolean":
+		if v, err := strconv.ParseBool(value); err == nil {
+			return v
+		}
+	case "integer":
+		if v, err := strconv.Atoi(value); err == nil {
+			return v
+		}
+	}
+	return value
+}
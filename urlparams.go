@@ -0,0 +1,35 @@
+package params
+
+import (
+	"net/url"
+
+	"github.com/Nivl/go-types/slices"
+)
+
+// LookupURLParams builds the url source of a params struct by calling lookup
+// with every key (name and aliases) of the fields using the url source.
+// Can be used with any router exposing a function to get a path variable
+// by name. Empty values are considered as not provided
+func LookupURLParams(data interface{}, lookup func(name string) string) url.Values {
+	values := url.Values{}
+	if data == nil {
+		return values
+	}
+
+	// An invalid struct will be reported when parsed
+	fields, err := New(data).Describe()
+	if err != nil {
+		return values
+	}
+	for _, field := range fields {
+		if !slices.HasString("url", field.Sources) {
+			continue
+		}
+		for _, key := range field.Options.Keys() {
+			if v := lookup(key); v != "" {
+				values.Set(key, v)
+			}
+		}
+	}
+	return values
+}
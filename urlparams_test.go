@@ -0,0 +1,51 @@
+package params_test
+
+import (
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	params "github.com/Nivl/go-params"
+)
+
+func TestLookupURLParams(t *testing.T) {
+	t.Parallel()
+
+	type Embedded struct {
+		OrgID string `from:"url" json:"org_id"`
+	}
+	type strct struct {
+		Embedded
+		ID      string `from:"url" json:"id"`
+		ChildID string `from:"url"`
+		Ignored string `from:"url" json:"-"`
+		Missing string `from:"url" json:"missing"`
+		Slug    string `from:"url,query" json:"slug"`
+		Renamed string `from:"url" json:"new_name" alias:"old_name"`
+		Query   string `from:"query" json:"query"`
+	}
+
+	vars := map[string]string{
+		"org_id":   "org",
+		"id":       "42",
+		"ChildID":  "12",
+		"Ignored":  "nope",
+		"query":    "nope",
+		"slug":     "my-slug",
+		"old_name": "old",
+	}
+	lookup := func(name string) string {
+		return vars[name]
+	}
+
+	values := params.LookupURLParams(&strct{}, lookup)
+	expected := url.Values{
+		"org_id":   []string{"org"},
+		"id":       []string{"42"},
+		"ChildID":  []string{"12"},
+		"slug":     []string{"my-slug"},
+		"old_name": []string{"old"},
+	}
+	assert.Equal(t, expected, values, "LookupURLParams() returned unexpected values")
+}
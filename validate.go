@@ -0,0 +1,199 @@
+package params
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/Nivl/go-params/formfile"
+	"github.com/Nivl/go-params/perror"
+)
+
+// Validate checks the current values of a struct against the rules set
+// in its tags, and runs its custom validation. The values are not
+// modified. data needs to be a struct or a pointer to a struct
+func Validate(data interface{}) error {
+	return New(data).Validate()
+}
+
+// Validate checks the current values of the struct against the rules set
+// in its tags, and runs its custom validation, without modifying
+// anything. The errors are the same as the ones returned by Parse.
+// The nil pointers and the nil slices are treated as missing params, and
+// get the default value. The other values, including the zero values,
+// are treated as provided. Only the metadata of the files are checked
+// (size, extension, and mime type)
+func (p *Params) Validate() error {
+	paramList, err := p.target(true)
+	if err != nil {
+		return err
+	}
+
+	if err := p.validateRecursive(paramList, false); err != nil {
+		return err
+	}
+	return customValidation(paramList)
+}
+
+// validateRecursive validates the fields of a struct. missing means the
+// struct is a nil embedded struct, and that none of its params have been
+// provided
+func (p *Params) validateRecursive(paramList reflect.Value, missing bool) error {
+	nbParams := paramList.NumField()
+	for i := 0; i < nbParams; i++ {
+		value := paramList.Field(i)
+		info := paramList.Type().Field(i)
+		tags := info.Tag
+
+		// We make sure we can read the value of field
+		if info.PkgPath != "" {
+			return fmt.Errorf("field %s could not be read", info.Name)
+		}
+
+		// Handle embedded struct
+		if isEmbeddedStruct(info) {
+			// A nil struct is validated as if it was parsed without data
+			embeddedMissing := missing
+			if value.Kind() == reflect.Ptr {
+				if value.IsNil() {
+					value = reflect.New(info.Type.Elem())
+					embeddedMissing = true
+				}
+				value = value.Elem()
+			}
+
+			if err := p.validateRecursive(value, embeddedMissing); err != nil {
+				return err
+			}
+			if err := customValidation(value); err != nil {
+				return err
+			}
+			continue
+		}
+
+		paramLocation := strings.ToLower(tags.Get("from"))
+		if paramLocation == "" {
+			return fmt.Errorf("no source set for field %s", info.Name)
+		}
+
+		opts, err := NewOptions(&tags)
+		if err != nil {
+			return err
+		}
+		// The tag needs to be ignored
+		if opts.Ignore {
+			continue
+		}
+		if opts.Name == "" {
+			opts.Name = p.fieldName(info)
+		}
+
+		if paramLocation == "file" {
+			err = validateFiles(value, opts)
+		} else {
+			err = validateValue(value, opts, tags.Get("default"), missing)
+		}
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// validateValue checks the value of a field against its options.
+// missing means the param has not been provided, whatever its value
+func validateValue(value reflect.Value, opts *Options, defaultValue string, missing bool) error {
+	// Only the nil pointers and the nil slices are treated as missing
+	// params, since a zero value can be a valid value (false, 0, ...)
+	provided := !missing
+	if value.Kind() == reflect.Ptr || value.Kind() == reflect.Slice {
+		provided = provided && !value.IsNil()
+	}
+	field := reflect.Indirect(value)
+
+	// We format the value to use the same code as Parse
+	if field.Kind() == reflect.Slice {
+		var values []string
+		if provided {
+			values = make([]string, field.Len())
+			for i := 0; i < field.Len(); i++ {
+				values[i] = formatValue(field.Index(i))
+			}
+		}
+		_, err := opts.ProcessValues(values, provided, defaultValue)
+		return err
+	}
+
+	formatted := ""
+	if provided {
+		formatted = formatValue(field)
+	}
+	_, err := opts.ProcessValue(formatted, provided, defaultValue)
+	return err
+}
+
+// validateFiles checks the files of a field against its options
+func validateFiles(value reflect.Value, opts *Options) error {
+	switch files := value.Interface().(type) {
+	case *formfile.FormFile:
+		if files == nil {
+			if opts.Required {
+				return perror.New(opts.Name, ErrMsgMissingParameter)
+			}
+			return nil
+		}
+		return validateFile(files, opts)
+	case []*formfile.FormFile:
+		if err := opts.ValidateFileCount(len(files)); err != nil {
+			return err
+		}
+		for _, f := range files {
+			if err := validateFile(f, opts); err != nil {
+				return err
+			}
+		}
+		return nil
+	default:
+		return fmt.Errorf("the only accepted type for a file is *formfile.FormFile or []*formfile.FormFile, got %T", files)
+	}
+}
+
+// validateFile checks the metadata of a file against the options
+func validateFile(ff *formfile.FormFile, opts *Options) error {
+	if ff == nil {
+		return nil
+	}
+	if ff.Header != nil {
+		if err := opts.ValidateFileSize(ff.Header.Size); err != nil {
+			return err
+		}
+		if err := opts.ValidateExtension(ff.Header.Filename); err != nil {
+			return err
+		}
+	}
+	if ff.Mime != "" {
+		if err := opts.ValidateMime(ff.Mime); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// customValidation runs the custom validator of a struct, if it has one.
+// A copy of the struct is used if its address cannot be taken, to make
+// sure the methods using a pointer receiver are available
+func customValidation(value reflect.Value) error {
+	if !value.CanAddr() {
+		copied := reflect.New(value.Type()).Elem()
+		copied.Set(value)
+		value = copied
+	}
+
+	if validator, ok := value.Addr().Interface().(CustomValidation); ok {
+		isValid, field, err := validator.IsValid()
+		if !isValid {
+			return perror.New(field, err.Error())
+		}
+	}
+	return nil
+}
@@ -0,0 +1,187 @@
+package params_test
+
+import (
+	"errors"
+	"mime/multipart"
+	"testing"
+
+	params "github.com/Nivl/go-params"
+	"github.com/Nivl/go-params/formfile"
+	"github.com/Nivl/go-params/perror"
+	"github.com/Nivl/go-types/ptrs"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type ValidatePagination struct {
+	Page int `from:"query" json:"page" min_int:"1" default:"1"`
+}
+
+func (p *ValidatePagination) IsValid() (isValid bool, fieldFailing string, err error) {
+	if p.Page > 100 {
+		return false, "page", errors.New("page too far")
+	}
+	return true, "", nil
+}
+
+type validateParams struct {
+	*ValidatePagination
+	ID     string             `from:"url" json:"id" params:"uuid,required"`
+	Name   *string            `from:"form" json:"name" params:"trim,noempty" maxlen:"10"`
+	Sort   string             `from:"query" json:"sort" enum:"name,date"`
+	Tags   []string           `from:"query" json:"tags" params:"no_empty_items" max_items:"2"`
+	Count  *int               `from:"query" json:"count" max_int:"10"`
+	Cover  *formfile.FormFile `from:"file" json:"cover" mime:"image/png"`
+	Secret string             `from:"form" json:"-" params:"required"`
+}
+
+func (p validateParams) IsValid() (isValid bool, fieldFailing string, err error) {
+	if p.Sort == "date" && p.Count != nil {
+		return false, "count", errors.New("cannot be used with the date sort")
+	}
+	return true, "", nil
+}
+
+func TestValidateStruct(t *testing.T) {
+	t.Parallel()
+
+	validID := "a0a0a0a0-a0a0-4a0a-8a0a-a0a0a0a0a0a0"
+	testCases := []struct {
+		description string
+		data        *validateParams
+		field       string
+		message     string
+	}{
+		{"valid struct", &validateParams{ID: validID}, "", ""},
+		{
+			"valid struct with all the params",
+			&validateParams{
+				ValidatePagination: &ValidatePagination{Page: 2},
+				ID:                 validID,
+				Name:               ptrs.NewString(" name "),
+				Sort:               "name",
+				Tags:               []string{"a", "b"},
+				Count:              ptrs.NewInt(3),
+				Cover:              &formfile.FormFile{Mime: "image/png"},
+			},
+			"", "",
+		},
+		{"missing required", &validateParams{}, "id", params.ErrMsgMissingParameter},
+		{"invalid uuid", &validateParams{ID: "nope"}, "id", params.ErrMsgInvalidUUID},
+		{"empty after trim", &validateParams{ID: validID, Name: ptrs.NewString("  ")}, "name", params.ErrMsgEmptyParameter},
+		{"string too long", &validateParams{ID: validID, Name: ptrs.NewString("abcdefghijklmnop")}, "name", params.ErrMsgMaxLen},
+		{"invalid enum", &validateParams{ID: validID, Sort: "nope"}, "sort", params.ErrMsgEnum},
+		{"empty item", &validateParams{ID: validID, Tags: []string{"a", ""}}, "tags", params.ErrMsgEmptyItem},
+		{"too many items", &validateParams{ID: validID, Tags: []string{"a", "b", "c"}}, "tags", params.ErrMsgArrayTooBig},
+		{"int too big", &validateParams{ID: validID, Count: ptrs.NewInt(11)}, "count", params.ErrMsgIntegerTooBig},
+		{"int too small", &validateParams{ValidatePagination: &ValidatePagination{Page: -1}, ID: validID}, "page", params.ErrMsgIntegerTooSmall},
+		{"invalid mime", &validateParams{ID: validID, Cover: &formfile.FormFile{Mime: "image/gif"}}, "cover", params.ErrMsgInvalidMime},
+		{"embedded custom validation", &validateParams{ValidatePagination: &ValidatePagination{Page: 101}, ID: validID}, "page", "page too far"},
+		{"custom validation", &validateParams{ID: validID, Sort: "date", Count: ptrs.NewInt(1)}, "count", "cannot be used with the date sort"},
+	}
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.description, func(t *testing.T) {
+			t.Parallel()
+
+			err := params.Validate(tc.data)
+			if tc.message == "" {
+				assert.NoError(t, err, "Validate() should have succeed")
+				return
+			}
+			require.Error(t, err, "Validate() should have failed")
+			perr, ok := err.(perror.Error)
+			require.True(t, ok, "the error should be a perror.Error")
+			assert.Equal(t, tc.field, perr.Field(), "wrong field")
+			assert.Equal(t, tc.message, perr.Error(), "wrong message")
+		})
+	}
+}
+
+func TestValidateZeroValues(t *testing.T) {
+	t.Parallel()
+
+	type strct struct {
+		Enabled  bool     `from:"query" json:"enabled" params:"required"`
+		Count    int      `from:"query" json:"count" params:"required"`
+		Limit    *int     `from:"query" json:"limit" params:"required"`
+		Tags     []string `from:"query" json:"tags" params:"noempty"`
+		Optional int      `from:"query" json:"optional" min_int:"1" default:"5"`
+	}
+
+	testCases := []struct {
+		description string
+		data        *strct
+		field       string
+	}{
+		{"zero values", &strct{Limit: ptrs.NewInt(0), Optional: 1}, ""},
+		{"nil pointer", &strct{Optional: 1}, "limit"},
+		{"empty slice", &strct{Limit: ptrs.NewInt(0), Tags: []string{}, Optional: 1}, "tags"},
+		{"zero value with a default value", &strct{Limit: ptrs.NewInt(0)}, "optional"},
+	}
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.description, func(t *testing.T) {
+			t.Parallel()
+
+			err := params.Validate(tc.data)
+			if tc.field == "" {
+				assert.NoError(t, err, "Validate() should have succeed")
+				return
+			}
+			require.Error(t, err, "Validate() should have failed")
+			perr, ok := err.(perror.Error)
+			require.True(t, ok, "the error should be a perror.Error")
+			assert.Equal(t, tc.field, perr.Field(), "wrong field")
+		})
+	}
+}
+
+func TestValidateNoMutation(t *testing.T) {
+	t.Parallel()
+
+	data := validateParams{
+		ID:   "a0a0a0a0-a0a0-4a0a-8a0a-a0a0a0a0a0a0",
+		Name: ptrs.NewString(" name "),
+	}
+	require.NoError(t, params.Validate(&data), "Validate() should have succeed")
+	assert.Nil(t, data.ValidatePagination, "the embedded struct should not have been allocated")
+	assert.Equal(t, " name ", *data.Name, "the transformations should not have been applied")
+
+	// structs are accepted as well
+	assert.NoError(t, params.Validate(data), "Validate() should work with a struct")
+}
+
+func TestValidateFiles(t *testing.T) {
+	t.Parallel()
+
+	type filesParams struct {
+		Files []*formfile.FormFile `from:"file" json:"files" params:"required" max_items:"1" ext:"png"`
+	}
+
+	err := params.Validate(&filesParams{})
+	assert.Error(t, err, "missing files should fail")
+
+	err = params.Validate(&filesParams{Files: []*formfile.FormFile{{}, {}}})
+	assert.Error(t, err, "too many files should fail")
+
+	file := &formfile.FormFile{Header: &multipart.FileHeader{Filename: "file.gif"}}
+	err = params.Validate(&filesParams{Files: []*formfile.FormFile{file}})
+	assert.Error(t, err, "invalid extensions should fail")
+}
+
+func TestValidateErrors(t *testing.T) {
+	t.Parallel()
+
+	err := params.Validate(nil)
+	assert.True(t, errors.Is(err, params.ErrNilTarget), "nil should fail")
+
+	err = params.Validate("nope")
+	assert.True(t, errors.Is(err, params.ErrNotStructPointer), "strings should fail")
+
+	type noSource struct {
+		Name string `json:"name"`
+	}
+	err = params.Validate(&noSource{})
+	assert.Error(t, err, "fields without sources should fail")
+}
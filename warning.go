@@ -0,0 +1,18 @@
+package params
+
+// Warning represents a non blocking issue found while parsing the params,
+// like the use of a deprecated param
+type Warning struct {
+	// Field contains the name of the field in the payload
+	Field string
+
+	// Key contains the key used by the client to send the param. It can be
+	// an alias of the field
+	Key string
+
+	// Message contains the message set using the deprecated tag
+	Message string
+}
+
+// WarningHook is a function called every time a Warning is recorded
+type WarningHook func(w Warning)